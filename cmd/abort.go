@@ -14,21 +14,22 @@ var (
 	abortExample          = getRolloutActionExample(abortShortDescription, oceancd.AbortAction)
 
 	abortCmd = &cobra.Command{
-		Use:     oceancd.AbortAction + " ROLLOUT_ID",
+		Use:     oceancd.AbortAction + " " + rolloutIdUse,
 		Short:   abortShortDescription,
 		Long:    abortDescription,
 		Example: abortExample,
 		Args: func(cmd *cobra.Command, args []string) error {
 			return validateRolloutActionArgs(cmd, args)
 		},
-		Run: func(_ *cobra.Command, args []string) {
-			runRolloutAction(oceancd.AbortAction, args, "rolled back")
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRolloutAction(cmd.Context(), oceancd.AbortAction, args, "rolled back")
 		},
 	}
 )
 
 func init() {
 	rolloutCmd.AddCommand(abortCmd)
+	addRolloutTargetFlags(abortCmd)
 
 	// Here you will define your flags and configuration settings.
 
@@ -5,8 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"github.com/spf13/cobra"
-	fp "path/filepath"
 	"spot-oceancd-cli/pkg/oceancd"
+	"spot-oceancd-cli/pkg/oceancd/manifests"
 	"spot-oceancd-cli/pkg/utils"
 )
 
@@ -16,42 +16,92 @@ This resource will be created if it doesn't exist yet.
 JSON and YAML formats are accepted.
 
 Ocean CD api reference please visit https://docs.spot.io/api/#tag/Ocean-CD`
-	applyExamples = `For example files in json and yaml format please visit our repo https://github.com/spotinst/spot-oceancd-cli
-and see the samples dir`
+	applyExamples = `  # Apply all the manifests of a directory tree
+  oceancd apply -f ./manifests -R
+
+  # Apply the manifests given on the standard input, in json or yaml format
+  cat strategy.yaml | oceancd apply -f -
 
-	fileToApply string
+  # Apply a directory and delete the strategies and rollout specs named team-* that are no longer in it
+  oceancd apply -f ./manifests --prune --prune-kinds strategy,rolloutSpec --prune-prefix team-
+
+For example files in json and yaml format please visit our repo https://github.com/spotinst/spot-oceancd-cli
+and see the samples dir`
 
 	applyCmd = &cobra.Command{
-		Use:     "apply (-f FILENAME)",
+		Use:     "apply (-f FILENAME|DIRECTORY|GLOB|-)... [-R]",
 		Short:   "Apply a configuration to a resource by file name",
 		Long:    applyDescription,
 		Example: applyExamples,
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			validateToken(context.Background())
+			validateToken(cmd.Context())
 		},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			return validateFlags()
+			if err := validateFlags(); err != nil {
+				return err
+			}
+
+			return validatePruneFlags(cmd)
 		},
-		Run: func(cmd *cobra.Command, args []string) {
-			runApplyCmd(context.Background())
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runApplyCmd(cmd.Context())
 		},
 	}
 )
 
-func runApplyCmd(ctx context.Context) {
-	configHandler, err := utils.NewConfigHandler(utils.Options{PathToConfig: fileToApply})
-	if err != nil {
-		fmt.Printf("Failed to apply resource - %s\n", err.Error())
-		return
+func runApplyCmd(ctx context.Context) error {
+	if pruneOptions.Prune {
+		return runApplyWithPruneCmd(ctx)
+	}
+
+	return runResources(ctx, "apply", false, applyResource)
+}
+
+func applyResource(ctx context.Context, resource *manifests.Resource) error {
+	entityType, resourceName, resourceToApply := resource.EntityType, resource.Name, resource.Body
+
+	if isDryRun() {
+		found, changes, err := getLiveChanges(ctx, entityType, resourceName, resourceToApply[entityType])
+		if err != nil {
+			return err
+		}
+
+		if found == false {
+			fmt.Printf("Resource '%s/%s' would be created (dry run)\n", entityType, resourceName)
+			return nil
+		}
+
+		printDryRunUpdate(entityType, resourceName, changes)
+		return nil
 	}
 
-	err = configHandler.Handle(ctx, applyResource)
+	_, resourceErr := apiClient.GetEntity(ctx, entityType, resourceName)
+	if resourceErr != nil {
+		if oceancd.IsNotFound(resourceErr) {
+			err := apiClient.CreateResource(ctx, entityType, resourceToApply)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Successfully created resource '%s/%s'\n", entityType, resourceName)
+			return nil
+		}
+
+		return resourceErr
+	}
+
+	err := apiClient.UpdateResource(ctx, entityType, resourceName, resourceToApply)
 	if err != nil {
-		fmt.Printf("Failed to apply resource - %s\n", err.Error())
+		return err
 	}
+
+	fmt.Printf("Successfully updated resource '%s/%s'\n", entityType, resourceName)
+	return nil
 }
 
-func applyResource(ctx context.Context, resource map[string]interface{}) error {
+// parseResource returns the entity type and name of a manifest resource, given either with a kind field
+// or nested under its entity type, along with the request body creating or updating it
+func parseResource(resource map[string]interface{}) (string, string, map[string]interface{}, error) {
 	var resourceName string
 	var entityType string
 	var err error
@@ -59,65 +109,46 @@ func applyResource(ctx context.Context, resource map[string]interface{}) error {
 	resourceToApply := make(map[string]interface{})
 	kind, isKindExist := resource["kind"]
 	if isKindExist {
-		entityType, err = utils.GetOceanCdEntityKindByName(kind.(string))
+		kindName, _ := kind.(string)
+		entityType, err = utils.GetOceanCdEntityKindByName(kindName)
 		if err != nil {
-			return err
+			return "", "", nil, err
 		}
 
 		delete(resource, "kind")
-		resourceName = resource["name"].(string)
+		resourceName, _ = resource["name"].(string)
 		resourceToApply[entityType] = resource
 	} else if len(resource) == 1 {
 
 		for key, value := range resource {
 			entityType, err = utils.GetOceanCdEntityKindByName(key)
 			if err != nil {
-				return err
+				return "", "", nil, err
 			}
 
-			resourceName = value.(map[string]interface{})["name"].(string)
-			resourceToApply[entityType] = value
-		}
-	} else {
-		return errors.New("error: Unknown resource type")
-	}
-
-	_, resourceErr := oceancd.GetEntity(ctx, entityType, resourceName)
-	if resourceErr != nil {
-		if resourceErr.Error() == "resource does not exist" {
-			err = oceancd.CreateResource(ctx, entityType, resourceToApply)
-			if err != nil {
-				return err
+			if fields, ok := value.(map[string]interface{}); ok {
+				resourceName, _ = fields["name"].(string)
 			}
 
-			fmt.Printf("Successfully created resource '%s/%s'\n", entityType, resourceName)
-			return nil
+			resourceToApply[entityType] = value
 		}
-
-		return resourceErr
+	} else {
+		return "", "", nil, errors.New("error: Unknown resource type")
 	}
 
-	err = oceancd.UpdateResource(ctx, entityType, resourceName, resourceToApply)
-	if err != nil {
-		return err
+	if resourceName == "" {
+		return "", "", nil, fmt.Errorf("error: Name of the %s resource not specified", entityType)
 	}
 
-	fmt.Printf("Successfully updated resource '%s/%s'\n", entityType, resourceName)
-	return nil
+	return entityType, resourceName, resourceToApply, nil
 }
 
 func validateFlags() error {
-	if fileToApply == "" {
-		fmt.Println("You must specify a file using -f")
-		return errors.New("error: Required file not specified")
-	}
-
-	fileExtensionWithDot := fp.Ext(fileToApply)
-	if err := utils.IsFileTypeSupported(fileExtensionWithDot); err != nil {
+	if err := validateFileFlags(); err != nil {
 		return err
 	}
 
-	return nil
+	return validateDryRun()
 }
 
 func init() {
@@ -132,5 +163,8 @@ func init() {
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
 	// applyCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
-	applyCmd.Flags().StringVarP(&fileToApply, "file", "f", "", "manifest file with resource definition")
+	addFileFlags(applyCmd)
+	addConcurrencyFlag(applyCmd)
+	addDryRunFlag(applyCmd)
+	addPruneFlags(applyCmd)
 }
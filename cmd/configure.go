@@ -62,8 +62,8 @@ You can create different profiles for different tokens.`
 		Short:   "Modify oceancd config file",
 		Long:    configureDescription,
 		Example: configureExamples,
-		Run: func(cmd *cobra.Command, args []string) {
-			runConfigureCmd(context.Background())
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigureCmd(context.Background())
 		},
 	}
 )
@@ -76,13 +76,13 @@ type ConfigFileFields struct {
 	Namespace string
 }
 
-func runConfigureCmd(ctx context.Context) {
+func runConfigureCmd(ctx context.Context) error {
 	answers := ConfigFileFields{Url: url}
 	if isTokenFromConfig {
 		err := survey.Ask(tokenQuestion, &answers)
 		if err != nil {
 			fmt.Println(err.Error())
-			return
+			return newExitError(err)
 		}
 	} else {
 		answers.Token = token
@@ -92,7 +92,7 @@ func runConfigureCmd(ctx context.Context) {
 		err := survey.Ask(profileQuestion, &answers)
 		if err != nil {
 			fmt.Println(err.Error())
-			return
+			return newExitError(err)
 		}
 	} else {
 		answers.Profile = profile
@@ -102,7 +102,7 @@ func runConfigureCmd(ctx context.Context) {
 		err := survey.Ask(clusterIdQuestion, &answers)
 		if err != nil {
 			fmt.Println(err.Error())
-			return
+			return newExitError(err)
 		}
 	} else {
 		answers.ClusterId = clusterId
@@ -112,7 +112,7 @@ func runConfigureCmd(ctx context.Context) {
 		err := survey.Ask(namespaceQuestion, &answers)
 		if err != nil {
 			fmt.Println(err.Error())
-			return
+			return newExitError(err)
 		}
 	} else {
 		answers.Namespace = namespace
@@ -122,56 +122,58 @@ func runConfigureCmd(ctx context.Context) {
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
 		if err = os.MkdirAll(dir, 0755); err != nil {
 			fmt.Printf("Failed to create file '%s' - %s\n", configFile, err.Error())
-			return
+			return newExitError(err)
 		}
 	} else if err != nil {
 		fmt.Printf("Failed to verify if dir '%s' exists - %s\n", dir, err.Error())
+		return newExitError(err)
 	}
 
 	// Create or update configuration.
 	cfg, loadErr := ini.LooseLoad(configFile)
 	if loadErr != nil {
 		fmt.Printf("Failed to load file '%s' - %s\n", configFile, loadErr.Error())
-		return
+		return newExitError(loadErr)
 	}
 
 	// Create a new `default` section.
 	sec, secErr := cfg.NewSection(answers.Profile)
 	if secErr != nil {
 		fmt.Printf("Failed to create new section in '%s' - %s\n", answers.Profile, secErr.Error())
-		return
+		return newExitError(secErr)
 	}
 
 	// Create a new `token` key.
 	if _, err := sec.NewKey("token", answers.Token); err != nil {
 		fmt.Printf("Failed to create key '%s' - %s\n", answers.Token, err.Error())
-		return
+		return newExitError(err)
 	}
 
 	// Create a new `url` key.
 	if _, err := sec.NewKey("url", answers.Url); err != nil {
 		fmt.Printf("Failed to create key '%s' - %s\n", answers.Url, err.Error())
-		return
+		return newExitError(err)
 	}
 
 	// Create a new `clusterId` key.
 	if _, err := sec.NewKey("clusterId", answers.ClusterId); err != nil {
 		fmt.Printf("Failed to create key '%s' - %s\n", answers.ClusterId, err.Error())
-		return
+		return newExitError(err)
 	}
 
 	// Create a new `namespace` key.
 	if _, err := sec.NewKey("namespace", answers.Namespace); err != nil {
 		fmt.Printf("Failed to create key '%s' - %s\n", answers.Namespace, err.Error())
-		return
+		return newExitError(err)
 	}
 
 	// Write out configuration to a file.
 	if err := cfg.SaveTo(configFile); err != nil {
 		fmt.Printf("Failed to save file '%s' - %s\n", configFile, err.Error())
-		return
+		return newExitError(err)
 	}
 
+	return nil
 }
 
 func init() {
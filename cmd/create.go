@@ -2,11 +2,11 @@ package cmd
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"github.com/spf13/cobra"
+	"spot-oceancd-cli/pkg/diff"
 	"spot-oceancd-cli/pkg/oceancd"
-	"spot-oceancd-cli/pkg/utils"
+	"spot-oceancd-cli/pkg/oceancd/manifests"
 )
 
 var (
@@ -19,71 +19,47 @@ Ocean CD api reference please visit https://docs.spot.io/api/#tag/Ocean-CD`
 and see the samples dir`
 
 	createCmd = &cobra.Command{
-		Use:     "create (-f FILENAME)",
+		Use:     "create (-f FILENAME|DIRECTORY|GLOB|-)... [-R]",
 		Short:   "Create a resource by file name",
 		Long:    createDescription,
 		Example: createExamples,
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			validateToken(context.Background())
+			validateToken(cmd.Context())
 		},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return validateFlags()
 		},
-		Run: func(cmd *cobra.Command, args []string) {
-			runCreateCmd(context.Background())
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCreateCmd(cmd.Context())
 		},
 	}
 )
 
-func runCreateCmd(ctx context.Context) {
-	configHandler, err := utils.NewConfigHandler(utils.Options{PathToConfig: fileToApply})
-	if err != nil {
-		fmt.Printf("Failed to create resource - %s\n", err.Error())
-		return
-	}
-
-	err = configHandler.Handle(ctx, createResource)
-	if err != nil {
-		fmt.Printf("Failed to create resource - %s\n", err.Error())
-	}
+func runCreateCmd(ctx context.Context) error {
+	return runResources(ctx, "create", false, createResource)
 }
 
-func createResource(ctx context.Context, resource map[string]interface{}) error {
-	var resourceName string
-	var entityType string
-	var err error
-
-	resourceToCreate := make(map[string]interface{})
-	kind, isKindExist := resource["kind"]
-	if isKindExist {
-		entityType, err = utils.GetOceanCdEntityKindByName(kind.(string))
-		if err != nil {
-			return err
-		}
+func createResource(ctx context.Context, resource *manifests.Resource) error {
+	entityType, resourceName, resourceToCreate := resource.EntityType, resource.Name, resource.Body
 
-		delete(resource, "kind")
-		resourceName = resource["name"].(string)
-		resourceToCreate[entityType] = resource
-	} else if len(resource) == 1 {
+	_, resourceErr := apiClient.GetEntity(ctx, entityType, resourceName)
+	if resourceErr != nil {
 
-		for key, value := range resource {
-			entityType, err = utils.GetOceanCdEntityKindByName(key)
-			if err != nil {
-				return err
-			}
+		if oceancd.IsNotFound(resourceErr) {
+			if isDryRun() {
+				if _, err := diff.Normalize(resourceToCreate[entityType]); err != nil {
+					return err
+				}
 
-			resourceName = value.(map[string]interface{})["name"].(string)
-			resourceToCreate[entityType] = value
-		}
-	} else {
-		return errors.New("error: Unknown resource type")
-	}
+				fmt.Printf("Resource '%s/%s' would be created (dry run)\n", entityType, resourceName)
+				return nil
+			}
 
-	_, resourceErr := oceancd.GetEntity(ctx, entityType, resourceName)
-	if resourceErr != nil {
+			err := apiClient.CreateResource(ctx, entityType, resourceToCreate)
+			if oceancd.IsConflict(err) {
+				return fmt.Errorf("error: Resource '%s/%s' already exists: %w", entityType, resourceName, err)
+			}
 
-		if resourceErr.Error() == "resource does not exist" {
-			err = oceancd.CreateResource(ctx, entityType, resourceToCreate)
 			if err != nil {
 				return err
 			}
@@ -95,8 +71,7 @@ func createResource(ctx context.Context, resource map[string]interface{}) error
 		return resourceErr
 	}
 
-	fmt.Printf("Failed to create resource '%s/%s'. Resource already exists.\n", entityType, resourceName)
-	return nil
+	return fmt.Errorf("error: Resource '%s/%s' already exists", entityType, resourceName)
 }
 
 func init() {
@@ -111,5 +86,7 @@ func init() {
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
 	// createCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
-	createCmd.Flags().StringVarP(&fileToApply, "file", "f", "", "manifest file with resource definition")
+	addFileFlags(createCmd)
+	addConcurrencyFlag(createCmd)
+	addDryRunFlag(createCmd)
 }
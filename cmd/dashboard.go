@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/spf13/cobra"
+	"spot-oceancd-cli/pkg/oceancd"
+	"spot-oceancd-cli/viewcontroller"
+	"time"
+)
+
+type DashboardOptions struct {
+	ClusterId string
+	Namespace string
+	All       bool
+	Interval  time.Duration
+	NoColor   bool
+}
+
+// dashboardCmd represents the dashboard command
+var (
+	dashboardDescription = `Open a full-screen interactive dashboard of the active OceanCD rollouts across clusters.
+The rollouts are listed on the left and the phases and verifications of the selected one on the right.
+
+Keybindings:
+  ↑/↓, j/k     select a rollout, or scroll the verification details when focused
+  tab          switch the focus between the rollouts and the verification details
+  pgup/pgdn    scroll the verification details
+  p            promote the selected rollout
+  P            fully promote the selected rollout
+  s            pause the selected rollout
+  a            abort the selected rollout
+  r            retry the selected rollout
+  q, ctrl+c    quit
+
+Every rollout action asks for a confirmation before it is sent`
+	dashboardShortDescription = "Interactive dashboard of OceanCD rollouts"
+	dashboardExample          = fmt.Sprintf("  # %s\n  %s %s\n\n  # %s\n  %s %s",
+		"Open the dashboard of all the active rollouts", rootCmd.Name(), "dashboard",
+		"Open the dashboard of a cluster including the completed rollouts", rootCmd.Name(), "dashboard --cluster example-cluster --all")
+	dashboardOptions = DashboardOptions{}
+
+	dashboardCmd = &cobra.Command{
+		Use:     "dashboard",
+		Short:   dashboardShortDescription,
+		Long:    dashboardDescription,
+		Example: dashboardExample,
+		Args:    cobra.NoArgs,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			validateToken(cmd.Context())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDashboardCmd(cmd.Context())
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(dashboardCmd)
+
+	dashboardCmd.Flags().StringVar(&dashboardOptions.ClusterId, "cluster", "", "Only show rollouts of the given cluster id")
+	dashboardCmd.Flags().StringVar(&dashboardOptions.Namespace, "namespace", "", "Only show rollouts of the given namespace")
+	dashboardCmd.Flags().BoolVar(&dashboardOptions.All, "all", false, "Show the completed rollouts along with the active ones")
+	dashboardCmd.Flags().DurationVar(&dashboardOptions.Interval, "interval", viewcontroller.DefaultWatchInterval,
+		"The interval between rollout refreshes, growing while the rollouts do not change")
+	dashboardCmd.Flags().BoolVar(&dashboardOptions.NoColor, "no-color", false, "Do not colorize output")
+}
+
+func runDashboardCmd(ctx context.Context) error {
+	filter := oceancd.RolloutFilter{
+		ClusterId: dashboardOptions.ClusterId,
+		Namespace: dashboardOptions.Namespace,
+	}
+
+	controller := viewcontroller.NewDashboardViewController(rolloutRepository, filter, dashboardOptions.NoColor).
+		WithCompleted(dashboardOptions.All).
+		WithInterval(dashboardOptions.Interval)
+
+	if err := controller.Run(ctx); err != nil {
+		fmt.Printf("Failed to run the dashboard - %s\n", err.Error())
+		return newExitError(err)
+	}
+
+	return nil
+}
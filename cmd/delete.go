@@ -4,8 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"path/filepath"
-	"spot-oceancd-cli/pkg/oceancd"
+	"spot-oceancd-cli/pkg/oceancd/manifests"
 	"spot-oceancd-cli/pkg/utils"
 
 	"github.com/spf13/cobra"
@@ -19,96 +18,77 @@ JSON and YAML formats are accepted. Only one type of argument may be specified:
 	deleteExamples = `  # Delete a strategy using the type and name specified in strategy.json
   oceancd delete -f ./strategy.json
 
+  # Delete the resources of all the manifests of a directory tree
+  oceancd delete -f ./manifests -R
+
   # Delete strategies with names "baz" and "foo"
   oceancd delete stg baz foo`
-	fileTolDelete string
-
 	deleteCmd = &cobra.Command{
-		Use:     "delete ([-f FILENAME] | TYPE [(NAME)])",
+		Use:     "delete ([-f FILENAME|DIRECTORY|GLOB|-]... [-R] | TYPE [(NAME)])",
 		Short:   "Delete resources by file names or resource and names",
 		Long:    deleteDescription,
 		Example: deleteExamples,
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			validateToken(context.Background())
+			validateToken(cmd.Context())
 		},
 		Args: func(cmd *cobra.Command, args []string) error {
 			return validateDeleteArgs(cmd, args)
 		},
-		Run: func(cmd *cobra.Command, args []string) {
-			runDeleteCmd(context.Background(), args)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDeleteCmd(cmd.Context(), args)
 		},
 	}
 )
 
-func runDeleteCmd(ctx context.Context, args []string) {
-	if fileTolDelete != "" {
-		handleDeleteByFile(ctx)
-		return
+func runDeleteCmd(ctx context.Context, args []string) error {
+	if len(fileOptions.Files) > 0 {
+		return handleDeleteByFile(ctx)
 	}
 
-	handleDeleteByArgs(ctx, args)
+	return handleDeleteByArgs(ctx, args)
 }
 
-func handleDeleteByArgs(ctx context.Context, args []string) {
+func handleDeleteByArgs(ctx context.Context, args []string) error {
 	resourceType := args[0]
 	resourceNames := args[1:]
 
 	entityType, _ := utils.GetEntityKindByName(resourceType)
 
 	for _, resourceName := range resourceNames {
-		err := oceancd.DeleteEntity(ctx, entityType, resourceName)
+		if isDryRun() {
+			if err := dryRunDelete(ctx, entityType, resourceName); err != nil {
+				fmt.Printf("Failed to delete '%v/%v' - %s\n", entityType, resourceName, err.Error())
+				return newExitError(err)
+			}
+
+			continue
+		}
+
+		err := apiClient.DeleteEntity(ctx, entityType, resourceName)
 		if err != nil {
 			fmt.Printf("Failed to delete '%v/%v' - %s\n", entityType, resourceName, err.Error())
-			return
+			return newExitError(err)
 		}
 
 		fmt.Printf("Successfully deleted resource '%v/%v'\n", entityType, resourceName)
 	}
-}
 
-func handleDeleteByFile(ctx context.Context) {
-	configHandler, err := utils.NewConfigHandler(utils.Options{PathToConfig: fileTolDelete})
-	if err != nil {
-		fmt.Printf("Failed to delete resource - %s\n", err.Error())
-		return
-	}
-
-	err = configHandler.Handle(ctx, deleteResource)
-	if err != nil {
-		fmt.Printf("Failed to delete resource - %s\n", err.Error())
-	}
+	return nil
 }
 
-func deleteResource(ctx context.Context, resource map[string]interface{}) error {
-	var resourceName string
-	var entityType string
-	var err error
-
-	kind, isKindExist := resource["kind"]
-	if isKindExist {
-		entityType, err = utils.GetOceanCdEntityKindByName(kind.(string))
-		if err != nil {
-			return err
-		}
-
-		resourceName = resource["name"].(string)
-	} else if len(resource) == 1 {
+func handleDeleteByFile(ctx context.Context) error {
+	return runResources(ctx, "delete", true, deleteResource)
+}
 
-		for key, value := range resource {
-			entityType, err = utils.GetOceanCdEntityKindByName(key)
-			if err != nil {
-				return err
-			}
+func deleteResource(ctx context.Context, resource *manifests.Resource) error {
+	entityType, resourceName := resource.EntityType, resource.Name
 
-			resourceName = value.(map[string]interface{})["name"].(string)
-		}
-	} else {
-		return errors.New("error: Unknown resource type")
+	if isDryRun() {
+		return dryRunDelete(ctx, entityType, resourceName)
 	}
 
-	err = oceancd.DeleteEntity(ctx, entityType, resourceName)
+	err := apiClient.DeleteEntity(ctx, entityType, resourceName)
 	if err != nil {
-		err = errors.New(fmt.Sprintf("'%v/%v' - %s\n", entityType, resourceName, err.Error()))
 		return err
 	}
 
@@ -130,35 +110,35 @@ func init() {
 	// is called directly, e.g.:
 	// deleteCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 
-	deleteCmd.Flags().StringVarP(&fileTolDelete, "file", "f", "", "manifest file with resource definition")
+	addFileFlags(deleteCmd)
+	addConcurrencyFlag(deleteCmd)
+	addDryRunFlag(deleteCmd)
 }
 
 func validateDeleteArgs(cmd *cobra.Command, args []string) error {
-	if fileTolDelete != "" {
-		fileExtensionWithDot := filepath.Ext(fileTolDelete)
-
-		if err := utils.IsFileTypeSupported(fileExtensionWithDot); err != nil {
-			return err
-		}
+	if err := validateDryRun(); err != nil {
+		return err
+	}
 
-		return nil
+	if len(fileOptions.Files) > 0 {
+		return validateFileFlags()
 	}
 
 	if len(args) < 1 {
 		fmt.Println("You must specify either filename or resource type and name.")
-		return errors.New("error: Required arguments not specified")
+		return newUsageError(errors.New("error: Required arguments not specified"))
 	}
 
 	if len(args) < 2 {
 		fmt.Println("You must specify resource name.")
-		return errors.New("error: Required argument not specified")
+		return newUsageError(errors.New("error: Required argument not specified"))
 	}
 
 	entityType := args[0]
 	_, err := utils.GetOceanCdEntityKindByName(entityType)
 	if err != nil {
 		fmt.Printf("Unknown resource '%s'. Use \"oceancd api-resources\" for a complete list of supported resources.\n", entityType)
-		return err
+		return newUsageError(err)
 	}
 
 	return nil
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"spot-oceancd-cli/pkg/diff"
+	"spot-oceancd-cli/pkg/oceancd"
+	"spot-oceancd-cli/pkg/oceancd/manifests"
+)
+
+type DiffOptions struct {
+	NoColor bool
+}
+
+type diffSummary struct {
+	changed   int
+	new       int
+	unchanged int
+}
+
+var (
+	diffDescription = `Preview what apply would change, by comparing the resources of the given file or directory with the live ones.
+Every -f can be given a file, a directory, a glob or - for the standard input.
+The fields managed by the SaaS (id, createdAt and updatedAt) are left out, and only the fields declared by the manifests are compared.
+Exits with code 1 when there are differences and with a code above 1 when the diff failed, which makes it usable for gating merges`
+	diffExamples = fmt.Sprintf("  # %s\n  %s %s",
+		"Preview the changes of the manifests of a directory", rootCmd.Name(), "diff -f ./oceancd")
+	diffOptions = DiffOptions{}
+
+	diffCmd = &cobra.Command{
+		Use:     "diff (-f FILENAME|DIRECTORY|GLOB|-)... [-R]",
+		Short:   "Preview the changes apply would make to the live resources",
+		Long:    diffDescription,
+		Example: diffExamples,
+		Args:    cobra.NoArgs,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			validateToken(cmd.Context())
+		},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateFileFlags()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiffCmd(cmd.Context())
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	addFileFlags(diffCmd)
+	diffCmd.Flags().BoolVar(&diffOptions.NoColor, "no-color", false, "Do not colorize output")
+}
+
+func runDiffCmd(ctx context.Context) error {
+	if diffOptions.NoColor {
+		color.NoColor = true
+	}
+
+	resources, file, err := loadResources(ctx)
+	if err != nil {
+		return newDiffFailedError(failedFileError("diff", file, err))
+	}
+
+	summary := diffSummary{}
+	for _, resource := range resources {
+		if err = diffResource(ctx, resource, &summary); err != nil {
+			return newDiffFailedError(failedFileError("diff", resource.Source, err))
+		}
+	}
+
+	fmt.Printf("%d changed, %d new, %d unchanged\n", summary.changed, summary.new, summary.unchanged)
+
+	if summary.changed > 0 || summary.new > 0 {
+		return &ExitError{
+			Code: ExitCodeError,
+			Err:  fmt.Errorf("error: Found differences in %d resources", summary.changed+summary.new),
+		}
+	}
+
+	return nil
+}
+
+// newDiffFailedError moves a generic failure off the exit code 1, which tells that differences were found
+func newDiffFailedError(err error) error {
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) && exitErr.Code == ExitCodeError {
+		return &ExitError{Code: ExitCodeDiffFailed, Err: exitErr.Err}
+	}
+
+	return err
+}
+
+func diffResource(ctx context.Context, resource *manifests.Resource, summary *diffSummary) error {
+	entityType, resourceName := resource.EntityType, resource.Name
+
+	desired, err := diff.Normalize(resource.Body[entityType])
+	if err != nil {
+		return err
+	}
+
+	resourceId := fmt.Sprintf("%s/%s", entityType, resourceName)
+
+	liveResource, err := apiClient.GetEntity(ctx, entityType, resourceName)
+	if err != nil {
+		if oceancd.IsNotFound(err) == false {
+			return err
+		}
+
+		summary.new++
+		fmt.Printf("%s %s\n", color.New(color.Bold).Sprint(resourceId), color.GreenString("(new)"))
+		printChanges(diff.Compare(map[string]interface{}{}, desired))
+		fmt.Println()
+
+		return nil
+	}
+
+	live, err := diff.Normalize(liveResource)
+	if err != nil {
+		return err
+	}
+
+	changes := diff.Compare(live, desired)
+	if len(changes) == 0 {
+		summary.unchanged++
+		fmt.Printf("%s %s\n\n", color.New(color.Bold).Sprint(resourceId), color.HiBlackString("(unchanged)"))
+
+		return nil
+	}
+
+	summary.changed++
+	fmt.Printf("%s %s\n", color.New(color.Bold).Sprint(resourceId), color.YellowString("(changed)"))
+	printChanges(changes)
+	fmt.Println()
+
+	return nil
+}
+
+func printChanges(changes []diff.Change) {
+	for _, change := range changes {
+		switch change.Type {
+		case diff.Added:
+			fmt.Println(color.GreenString("+ %s: %s", change.Path, diffValue(change.New)))
+		case diff.Removed:
+			fmt.Println(color.RedString("- %s: %s", change.Path, diffValue(change.Old)))
+		case diff.Modified:
+			fmt.Printf("%s %s\n", color.YellowString("~ %s:", change.Path),
+				color.RedString("%s", diffValue(change.Old))+" -> "+color.GreenString("%s", diffValue(change.New)))
+		}
+	}
+}
+
+func diffValue(value interface{}) string {
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(valueBytes)
+}
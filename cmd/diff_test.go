@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"spot-oceancd-cli/pkg/oceancd/manifests"
+	"testing"
+)
+
+const testStrategyManifest = `- strategy:
+    name: "canary"
+    canary:
+      steps:
+        - name: "first"
+          setWeight: 20
+`
+
+func TestDiffCommandExitCodes(t *testing.T) {
+	cases := map[string]struct {
+		status           int
+		body             string
+		expectedExitCode int
+	}{
+		"unchanged": {
+			status: http.StatusOK,
+			body:   `{"response": {"items": [{"name": "canary", "canary": {"steps": [{"name": "first", "setWeight": 20}]}}]}}`,
+		},
+		"changed": {
+			status:           http.StatusOK,
+			body:             `{"response": {"items": [{"name": "canary", "canary": {"steps": [{"name": "first", "setWeight": 40}]}}]}}`,
+			expectedExitCode: ExitCodeError,
+		},
+		"new": {
+			status:           http.StatusOK,
+			body:             `{"response": {"items": []}}`,
+			expectedExitCode: ExitCodeError,
+		},
+		"failed": {
+			status:           http.StatusInternalServerError,
+			body:             `{"response": {"errors": [{"code": "GENERAL", "message": "internal error"}]}}`,
+			expectedExitCode: ExitCodeDiffFailed,
+		},
+		"unauthorized": {
+			status:           http.StatusUnauthorized,
+			body:             `{"response": {"errors": [{"code": "UNAUTHORIZED", "message": "invalid token"}]}}`,
+			expectedExitCode: ExitCodeUnauthorized,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			manifest := filepath.Join(t.TempDir(), "strategy.yaml")
+			if err := os.WriteFile(manifest, []byte(testStrategyManifest), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			fileOptions = FileOptions{Concurrency: manifests.DefaultConcurrency}
+			diffOptions = DiffOptions{}
+			err := executeCommand(t, nil, "diff", "-f", manifest, "--no-color", "--url", server.URL, "--max-retries", "0")
+
+			var exitErr *ExitError
+			if tc.expectedExitCode == 0 && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			} else if tc.expectedExitCode != 0 && (errors.As(err, &exitErr) == false || exitErr.Code != tc.expectedExitCode) {
+				t.Fatalf("expected exit code %d, got %v", tc.expectedExitCode, err)
+			}
+		})
+	}
+}
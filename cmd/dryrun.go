@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/spf13/cobra"
+	"spot-oceancd-cli/pkg/diff"
+	"spot-oceancd-cli/pkg/oceancd"
+	"spot-oceancd-cli/pkg/utils"
+)
+
+const (
+	DryRunNone   = "none"
+	DryRunClient = "client"
+	DryRunServer = "server"
+
+	dryRunFlagLabel = "dry-run"
+)
+
+var dryRun = DryRunNone
+
+// addDryRunFlag lets a command only print the action it would take on every resource.
+// A bare --dry-run is a client one.
+func addDryRunFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&dryRun, dryRunFlagLabel, DryRunNone,
+		`One of: none|client|server. With "client", every resource is parsed and validated, and the action that would be taken is printed without changing anything`)
+	cmd.Flags().Lookup(dryRunFlagLabel).NoOptDefVal = DryRunClient
+}
+
+func validateDryRun() error {
+	switch dryRun {
+	case DryRunNone, DryRunClient:
+		return nil
+	case DryRunServer:
+		fmt.Println("The Ocean CD API has no validation endpoint to run a server dry run against, use --dry-run=client instead.")
+		return newUsageError(fmt.Errorf("error: Unsupported dry run %s", dryRun))
+	default:
+		fmt.Printf("Unknown dry run '%s'. Please choose one of: %s|%s|%s\n", dryRun, DryRunNone, DryRunClient, DryRunServer)
+		return newUsageError(fmt.Errorf("error: Unknown dry run %s", dryRun))
+	}
+}
+
+func isDryRun() bool {
+	return dryRun == DryRunClient
+}
+
+// getLiveChanges compares the desired resource with the live one, found tells whether the live one exists
+func getLiveChanges(ctx context.Context, entityType string, resourceName string, desiredResource interface{}) (bool, []diff.Change, error) {
+	desired, err := diff.Normalize(desiredResource)
+	if err != nil {
+		return false, nil, err
+	}
+
+	liveResource, err := apiClient.GetEntity(ctx, entityType, resourceName)
+	if err != nil {
+		if oceancd.IsNotFound(err) {
+			return false, nil, nil
+		}
+
+		return false, nil, err
+	}
+
+	live, err := diff.Normalize(liveResource)
+	if err != nil {
+		return false, nil, err
+	}
+
+	return true, diff.Compare(live, desired), nil
+}
+
+func dryRunDelete(ctx context.Context, entityType string, resourceName string) error {
+	if _, err := apiClient.GetEntity(ctx, entityType, resourceName); err != nil {
+		return err
+	}
+
+	fmt.Printf("Resource '%s/%s' would be deleted (dry run)\n", entityType, resourceName)
+	return nil
+}
+
+func printDryRunUpdate(entityType string, resourceName string, changes []diff.Change) {
+	if len(changes) == 0 {
+		fmt.Printf("Resource '%s/%s' is unchanged (dry run)\n", entityType, resourceName)
+		return
+	}
+
+	fmt.Printf("Resource '%s/%s' would be updated, %d %s changed (dry run)\n",
+		entityType, resourceName, len(changes), utils.GetNounForm("field", len(changes)))
+}
@@ -2,11 +2,10 @@ package cmd
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"github.com/spf13/cobra"
 	"spot-oceancd-cli/pkg/oceancd"
-	"spot-oceancd-cli/pkg/utils"
+	"spot-oceancd-cli/pkg/oceancd/manifests"
 )
 
 var (
@@ -18,78 +17,54 @@ Ocean CD api reference please visit https://docs.spot.io/api/#tag/Ocean-CD`
 and see the samples dir`
 
 	editCmd = &cobra.Command{
-		Use:     "edit (-f FILENAME)",
+		Use:     "edit (-f FILENAME|DIRECTORY|GLOB|-)... [-R]",
 		Short:   "Edit a resource by file name",
 		Long:    editDescription,
 		Example: editExamples,
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			validateToken(context.Background())
+			validateToken(cmd.Context())
 		},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return validateFlags()
 		},
-		Run: func(cmd *cobra.Command, args []string) {
-			runEditCmd(context.Background())
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEditCmd(cmd.Context())
 		},
 	}
 )
 
-func runEditCmd(ctx context.Context) {
-	configHandler, err := utils.NewConfigHandler(utils.Options{PathToConfig: fileToApply})
-	if err != nil {
-		fmt.Printf("Failed to edit resource - %s\n", err.Error())
-		return
-	}
-
-	err = configHandler.Handle(ctx, editResource)
-	if err != nil {
-		fmt.Printf("Failed to edit resource - %s\n", err.Error())
-	}
+func runEditCmd(ctx context.Context) error {
+	return runResources(ctx, "edit", false, editResource)
 }
 
-func editResource(ctx context.Context, resource map[string]interface{}) error {
-	var resourceName string
-	var entityType string
-	var err error
+func editResource(ctx context.Context, resource *manifests.Resource) error {
+	entityType, resourceName, resourceToEdit := resource.EntityType, resource.Name, resource.Body
 
-	resourceToEdit := make(map[string]interface{})
-	kind, isKindExist := resource["kind"]
-	if isKindExist {
-		entityType, err = utils.GetOceanCdEntityKindByName(kind.(string))
+	if isDryRun() {
+		found, changes, err := getLiveChanges(ctx, entityType, resourceName, resourceToEdit[entityType])
 		if err != nil {
 			return err
 		}
 
-		delete(resource, "kind")
-		resourceName = resource["name"].(string)
-		resourceToEdit[entityType] = resource
-	} else if len(resource) == 1 {
-
-		for key, value := range resource {
-			entityType, err = utils.GetOceanCdEntityKindByName(key)
-			if err != nil {
-				return err
-			}
-
-			resourceName = value.(map[string]interface{})["name"].(string)
-			resourceToEdit[entityType] = value
+		if found == false {
+			return fmt.Errorf("error: Resource '%s/%s' doesn't exist", entityType, resourceName)
 		}
-	} else {
-		return errors.New("error: Unknown resource type")
+
+		printDryRunUpdate(entityType, resourceName, changes)
+		return nil
 	}
 
-	_, resourceErr := oceancd.GetEntity(ctx, entityType, resourceName)
+	_, resourceErr := apiClient.GetEntity(ctx, entityType, resourceName)
 	if resourceErr != nil {
 
-		if resourceErr.Error() == "resource does not exist" {
-			fmt.Printf("Failed to edit resource '%s/%s'. Resource doesn't exist.\n", entityType, resourceName)
-			return nil
+		if oceancd.IsNotFound(resourceErr) {
+			return fmt.Errorf("error: Resource '%s/%s' doesn't exist: %w", entityType, resourceName, resourceErr)
 		}
 
 		return resourceErr
 	}
 
-	err = oceancd.UpdateResource(ctx, entityType, resourceName, resourceToEdit)
+	err := apiClient.UpdateResource(ctx, entityType, resourceName, resourceToEdit)
 	if err != nil {
 		return err
 	}
@@ -110,5 +85,7 @@ func init() {
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
 	// editCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
-	editCmd.Flags().StringVarP(&fileToApply, "file", "f", "", "manifest file with resource definition")
+	addFileFlags(editCmd)
+	addConcurrencyFlag(editCmd)
+	addDryRunFlag(editCmd)
 }
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"spot-oceancd-cli/pkg/oceancd"
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+)
+
+// Process exit codes, scripts may rely on them
+const (
+	ExitCodeSuccess       = 0
+	ExitCodeError         = 1
+	ExitCodeUsage         = 2
+	ExitCodeNotFound      = 3
+	ExitCodeUnauthorized  = 4
+	ExitCodeRolloutFailed = 5
+	// ExitCodeDiffFailed is the generic error of diff, which keeps ExitCodeError for the differences it finds
+	ExitCodeDiffFailed = 6
+)
+
+// ExitError is returned by a command that has already reported its failure to the user.
+// It only carries the exit code the process should end with.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit code %d", e.Code)
+	}
+
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+func newExitError(err error) error {
+	return &ExitError{Code: exitCodeOf(err), Err: err}
+}
+
+// newUsageError fails the command for invalid arguments or flags, already reported to the user
+func newUsageError(err error) error {
+	return &ExitError{Code: ExitCodeUsage, Err: err}
+}
+
+func exitCodeOf(err error) int {
+	switch {
+	case err == nil:
+		return ExitCodeSuccess
+	case oceancd.IsNotFound(err):
+		return ExitCodeNotFound
+	case oceancd.IsUnauthorized(err), oceancd.IsForbidden(err):
+		return ExitCodeUnauthorized
+	default:
+		return ExitCodeError
+	}
+}
+
+// newRolloutStatusExitError fails the command when the rollout ended unsuccessfully
+func newRolloutStatusExitError(rolloutId string, status rollout.Status) error {
+	if status.IsUnsuccessful() == false {
+		return nil
+	}
+
+	return &ExitError{
+		Code: ExitCodeRolloutFailed,
+		Err:  fmt.Errorf("error: Rollout %s is %s", rolloutId, status),
+	}
+}
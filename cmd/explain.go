@@ -56,16 +56,16 @@ func runExplainCmd(ctx context.Context, args []string) {
 func validateExplainArgs(cmd *cobra.Command, args []string) error {
 	if len(args) < 1 {
 		fmt.Println("You must specify the type of resource to explain. Use \"oceancd api-resources\" for a complete list of supported resources.")
-		return errors.New("error: Required resource not specified")
+		return newUsageError(errors.New("error: Required resource not specified"))
 	} else if len(args) > 1 {
 		fmt.Println("You can only specify one type of resource to explain. Use \"oceancd api-resources\" for a complete list of supported resources.")
-		return errors.New("error: Too many resources")
+		return newUsageError(errors.New("error: Too many resources"))
 	} else {
 		entityType := args[0]
 		_, err := utils.GetOceanCdEntityKindByName(entityType)
 		if err != nil {
 			fmt.Printf("Unknown resource type '%s'. Use \"oceancd api-resources\" for a complete list of supported resources.\n", entityType)
-			return err
+			return newUsageError(err)
 		}
 	}
 
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"spot-oceancd-cli/pkg/oceancd/manifests"
+	"spot-oceancd-cli/pkg/utils"
+)
+
+type FileOptions struct {
+	Files       []string
+	Recursive   bool
+	Concurrency int
+}
+
+type resourceResult struct {
+	Resource string `header:"Resource"`
+	File     string `header:"File"`
+	Result   string `header:"Result"`
+}
+
+var fileOptions = FileOptions{Concurrency: manifests.DefaultConcurrency}
+
+func addFileFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVarP(&fileOptions.Files, "file", "f", nil,
+		"manifest file, directory or glob with resource definitions, - reads them from stdin. Can be repeated")
+	cmd.Flags().BoolVarP(&fileOptions.Recursive, "recursive", "R", false, "Process the directories given with -f recursively")
+}
+
+// addConcurrencyFlag lets a command handle the independent resources of its files in parallel
+func addConcurrencyFlag(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&fileOptions.Concurrency, "concurrency", manifests.DefaultConcurrency,
+		"Maximum number of resources handled at the same time. Resources wait for the ones they reference")
+}
+
+func validateFileFlags() error {
+	if len(fileOptions.Files) == 0 {
+		fmt.Println("You must specify a file using -f")
+		return newUsageError(errors.New("error: Required file not specified"))
+	}
+
+	if fileOptions.Concurrency < 1 {
+		fmt.Printf("Invalid concurrency %d. The concurrency must be a positive number\n", fileOptions.Concurrency)
+		return newUsageError(fmt.Errorf("error: Invalid concurrency %d", fileOptions.Concurrency))
+	}
+
+	if _, err := utils.ConfigFiles(fileOptions.Files, fileOptions.Recursive); err != nil {
+		fmt.Printf("Invalid file - %s\n", err.Error())
+		return newUsageError(err)
+	}
+
+	return nil
+}
+
+// loadResources parses every resource of the files given with -f, in order, before any of them is handled.
+// It stops at the first invalid resource, and returns its file along with the error.
+func loadResources(ctx context.Context) ([]*manifests.Resource, string, error) {
+	files, err := utils.ConfigFiles(fileOptions.Files, fileOptions.Recursive)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var retVal []*manifests.Resource
+	for _, file := range files {
+		configHandler, err := utils.NewConfigHandler(utils.Options{PathToConfig: file})
+		if err != nil {
+			return nil, file, err
+		}
+
+		err = configHandler.Handle(ctx, func(ctx context.Context, resource map[string]interface{}) error {
+			entityType, resourceName, body, err := parseResource(resource)
+			if err != nil {
+				return err
+			}
+
+			retVal = append(retVal, &manifests.Resource{EntityType: entityType, Name: resourceName, Body: body, Source: file})
+			return nil
+		})
+		if err != nil {
+			return nil, file, err
+		}
+	}
+
+	return retVal, "", nil
+}
+
+// runResources handles the resources of the files given with -f in dependency order, the independent ones in parallel.
+// Deleting runs in reverse, so that a resource is deleted before the ones it references.
+// A failure does not stop the resources not depending on the failed one, and every result is reported at the end.
+func runResources(ctx context.Context, action string, reverse bool, fn func(ctx context.Context, resource *manifests.Resource) error) error {
+	resources, file, err := loadResources(ctx)
+	if err != nil {
+		return failedFileError(action, file, err)
+	}
+
+	return handleResources(ctx, action, resources, reverse, fn)
+}
+
+func handleResources(ctx context.Context, action string, resources []*manifests.Resource, reverse bool,
+	fn func(ctx context.Context, resource *manifests.Resource) error) error {
+	graph, err := manifests.NewGraph(resources)
+	if err != nil {
+		fmt.Printf("Failed to %s resources - %s\n", action, err.Error())
+		return newExitError(err)
+	}
+
+	return reportResults(action, graph.Run(ctx, fileOptions.Concurrency, reverse, fn))
+}
+
+func reportResults(action string, results []manifests.Result) error {
+	if len(results) == 1 {
+		if results[0].Err != nil {
+			fmt.Printf("Failed to %s '%s' - %s\n", action, results[0].Resource.Key(), results[0].Err.Error())
+			return newExitError(results[0].Err)
+		}
+
+		return nil
+	}
+
+	var firstErr error
+	succeeded, failed, skipped := 0, 0, 0
+	rows := make([]resourceResult, len(results))
+
+	for i, result := range results {
+		rows[i] = resourceResult{Resource: result.Resource.Key(), File: result.Resource.Source, Result: "succeeded"}
+
+		switch {
+		case result.Err != nil:
+			failed++
+			rows[i].Result = fmt.Sprintf("failed - %s", result.Err.Error())
+			if firstErr == nil {
+				firstErr = result.Err
+			}
+		case result.SkippedBy != "":
+			skipped++
+			rows[i].Result = fmt.Sprintf("skipped - %s failed", result.SkippedBy)
+		default:
+			succeeded++
+		}
+	}
+
+	fmt.Println()
+	newTablePrinter().Print(rows)
+	fmt.Printf("\n%d succeeded, %d failed, %d skipped\n", succeeded, failed, skipped)
+
+	if firstErr != nil {
+		return newExitError(firstErr)
+	}
+
+	return nil
+}
+
+// failedFileError prints the failure of a command on the resources of the given file
+func failedFileError(action string, file string, err error) error {
+	if file == "" || file == utils.StdinPath {
+		fmt.Printf("Failed to %s resource - %s\n", action, err.Error())
+	} else {
+		fmt.Printf("Failed to %s resource of %s - %s\n", action, file, err.Error())
+	}
+
+	return newExitError(err)
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/lensesio/tableprinter"
 	"github.com/spf13/cobra"
 	"os"
-	"spot-oceancd-cli/pkg/oceancd"
 	"spot-oceancd-cli/pkg/oceancd/model"
 	"spot-oceancd-cli/pkg/utils"
 )
@@ -41,10 +40,10 @@ Prints a table of the most important information about the specified resources.`
 			return validateGetArgs(cmd, args)
 		},
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			validateToken(context.Background())
+			validateToken(cmd.Context())
 		},
-		Run: func(cmd *cobra.Command, args []string) {
-			runGetCmd(context.Background(), args)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGetCmd(cmd.Context(), args)
 		},
 	}
 )
@@ -67,37 +66,37 @@ func init() {
 func validateGetArgs(cmd *cobra.Command, args []string) error {
 	if len(args) < 1 {
 		fmt.Println("You must specify the type of resource to get. Use \"oceancd api-resources\" for a complete list of supported resources.")
-		return errors.New("error: Required resource not specified")
+		return newUsageError(errors.New("error: Required resource not specified"))
 	} else {
 		entityType := args[0]
 		_, err := utils.GetEntityKindByName(entityType)
 		if err != nil {
 			fmt.Printf("Unknown resource type '%s'. Use \"oceancd api-resources\" for a complete list of supported resources.\n", entityType)
-			return err
+			return newUsageError(err)
 		}
 	}
 
 	return nil
 }
 
-func runGetCmd(ctx context.Context, args []string) {
+func runGetCmd(ctx context.Context, args []string) error {
 	var resources []interface{}
 	resourceType := args[0]
 	resourceNames := args[1:]
 
 	entityType, err := utils.GetEntityKindByName(resourceType)
 	if len(args) == 1 {
-		resources, err = oceancd.ListEntities(context.Background(), entityType)
+		resources, err = apiClient.ListEntities(ctx, entityType)
 		if err != nil {
 			fmt.Printf("Failed to get resource '%s' - %s\n", entityType, err.Error())
-			return
+			return newExitError(err)
 		}
 	} else {
 		for _, resourceName := range resourceNames {
-			resource, getErr := oceancd.GetEntity(context.Background(), entityType, resourceName)
+			resource, getErr := apiClient.GetEntity(ctx, entityType, resourceName)
 			if getErr != nil {
 				fmt.Printf("Failed to get resource '%s/%s' - %s\n", entityType, resourceName, getErr.Error())
-				return
+				return newExitError(getErr)
 			}
 
 			resources = append(resources, resource)
@@ -109,12 +108,14 @@ func runGetCmd(ctx context.Context, args []string) {
 		resourcesStr, yamlErr := utils.ConvertEntitiesToYamlString(resources)
 		if yamlErr != nil {
 			fmt.Printf("Failed to convert resources to yaml - %s\n", yamlErr.Error())
+			return newExitError(yamlErr)
 		}
 		fmt.Println(resourcesStr)
 	case "json":
 		resourcesStr, jsonErr := utils.ConvertEntitiesToJsonString(resources)
 		if jsonErr != nil {
 			fmt.Printf("Failed to convert resources to json - %s\n", jsonErr.Error())
+			return newExitError(jsonErr)
 		}
 		fmt.Println(resourcesStr)
 	case "wide":
@@ -124,6 +125,7 @@ func runGetCmd(ctx context.Context, args []string) {
 			resourcesStr, yamlErr := utils.ConvertEntitiesToYamlString(resources)
 			if yamlErr != nil {
 				fmt.Printf("Failed to convert resources to yaml - %s\n", yamlErr.Error())
+				return newExitError(yamlErr)
 			}
 			fmt.Println(resourcesStr)
 		} else {
@@ -131,18 +133,25 @@ func runGetCmd(ctx context.Context, args []string) {
 		}
 	default:
 		fmt.Printf("Unknown output '%s'. Please choose one of: json|yaml|wide\n", output)
+		return &ExitError{Code: ExitCodeUsage, Err: fmt.Errorf("error: Unknown output %s", output)}
 	}
 
-	return
+	return nil
 }
 
-func handlePrint(ctx context.Context, entityType string, resources []interface{}) {
+func newTablePrinter() *tableprinter.Printer {
 	printer := tableprinter.New(os.Stdout)
 	printer.BorderTop, printer.BorderBottom, printer.BorderLeft, printer.BorderRight = false, false, false, false
 	printer.CenterSeparator = " "
 	printer.ColumnSeparator = " "
 	printer.RowSeparator = " "
 
+	return printer
+}
+
+func handlePrint(ctx context.Context, entityType string, resources []interface{}) {
+	printer := newTablePrinter()
+
 	switch entityType {
 	case model.VerificationProviderEntity:
 		entitiesDetails := utils.GetVerificationProviderEntitiesDetails(resources)
@@ -12,7 +12,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	ctrl "sigs.k8s.io/controller-runtime"
-	"spot-oceancd-cli/pkg/oceancd"
 	"spot-oceancd-cli/pkg/oceancd/model/operator"
 	"spot-oceancd-cli/pkg/utils"
 	"spot-oceancd-operator-commons/handlers/cluster"
@@ -44,9 +43,9 @@ var (
 		Long:    operatorDeleteDescription,
 		Example: operatorDeleteExample,
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			validateToken(context.Background())
-			validateClusterId(context.Background())
-			validateClusterIdExists(context.Background())
+			validateToken(cmd.Context())
+			validateClusterId(cmd.Context(), clusterId)
+			validateClusterIdExists(cmd.Context())
 		},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return validateOperatorDeleteFlags(cmd)
@@ -54,14 +53,16 @@ var (
 		Args: func(cmd *cobra.Command, args []string) error {
 			return cobra.NoArgs(cmd, args)
 		},
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			fmt.Printf("Deleting OceanCD operator manager in cluster %s\n", clusterId)
 
-			if err := runOperatorDeleteCmd(context.Background(), cmd); err != nil {
+			if err := runOperatorDeleteCmd(cmd.Context(), cmd); err != nil {
 				fmt.Printf("Failed to delete OceanCD operator manager\n%s\n", err)
+				return newExitError(err)
 			}
 
 			fmt.Printf("OceanCD operator manager was deleted succesfully.\n")
+			return nil
 		},
 	}
 )
@@ -155,7 +156,7 @@ func deleteOperatorByConfig(ctx context.Context, data map[string]interface{}) er
 }
 
 func deleteOperator(ctx context.Context) error {
-	manifestsToDelete, err := oceancd.GetClusterManifests(ctx)
+	manifestsToDelete, err := apiClient.GetClusterManifests(ctx)
 	if err != nil {
 		return fmt.Errorf("error: Failed to fetch cluster manifests to delete\n%w", err)
 	}
@@ -183,7 +184,7 @@ func deleteOperator(ctx context.Context) error {
 		return fmt.Errorf("error: Failed to delete OceanCD operator manager manifests\n%w", err)
 	}
 
-	if _, err = oceancd.DeleteCluster(ctx, clusterId); err != nil {
+	if _, err = apiClient.DeleteCluster(ctx, clusterId); err != nil {
 		return fmt.Errorf("error: Failed to delete OceanCD cluster from saas\n%w", err)
 	}
 
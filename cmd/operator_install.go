@@ -8,7 +8,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/api/policy/v1beta1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -21,7 +20,6 @@ import (
 	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
-	"spot-oceancd-cli/pkg/oceancd"
 	"spot-oceancd-cli/pkg/oceancd/model/operator"
 	"spot-oceancd-cli/pkg/utils"
 	"spot-oceancd-operator-commons/component_configs"
@@ -48,9 +46,9 @@ var (
 		Long:    operatorInstallDescription,
 		Example: operatorInstallExample,
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			validateToken(context.Background())
-			validateClusterId(context.Background())
-			validateClusterIdNotExists(context.Background())
+			validateToken(cmd.Context())
+			validateClusterId(cmd.Context(), clusterId)
+			validateClusterIdNotExists(cmd.Context())
 		},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return validateOperatorInstallFlags(cmd)
@@ -58,14 +56,16 @@ var (
 		Args: func(cmd *cobra.Command, args []string) error {
 			return cobra.NoArgs(cmd, args)
 		},
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			fmt.Printf("Installing OceanCD operator manager in cluster %s\n", clusterId)
 
-			if err := runOperatorInstallCmd(context.Background(), cmd); err != nil {
+			if err := runOperatorInstallCmd(cmd.Context(), cmd); err != nil {
 				fmt.Printf("Failed to install OceanCD operator manager\n%s\n", err)
+				return newExitError(err)
 			}
 
 			fmt.Printf("OceanCD operator manager installation finished succesfully.\n")
+			return nil
 		},
 	}
 )
@@ -145,7 +145,7 @@ func installOperator(ctx context.Context, data map[string]interface{}) error {
 	}
 
 	payload := operator.NewOMManifestsRequest(config)
-	output, err := oceancd.GetOMInstallationManifests(ctx, payload)
+	output, err := apiClient.GetOMInstallationManifests(ctx, payload)
 	if err != nil {
 		return fmt.Errorf("error: Failed to fetch installation resources\n%w", err)
 	}
@@ -174,7 +174,7 @@ func installOperator(ctx context.Context, data map[string]interface{}) error {
 	applyHandler := cluster.BaseApplyHandler{}
 
 	if isOperatorInstallCommand {
-		clusterToken, err := oceancd.CreateClusterToken(ctx)
+		clusterToken, err := apiClient.CreateClusterToken(ctx, clusterId)
 		if err != nil {
 			return fmt.Errorf("error: Failed to create cluster token\n%w", err)
 		}
@@ -275,14 +275,12 @@ func isNamespaceExists(k8sClient k8sclient.Client, oceancdNamespace string) (boo
 }
 
 func buildOperatorManagerSecret(clusterToken *operator.ClusterTokenResponse, namespace string) *corev1.Secret {
-	clusterUrl := viper.GetString("clusterUrl")
-
 	retVal := &corev1.Secret{
 		TypeMeta:   v1.TypeMeta{Kind: string(v1beta1.Secret)},
 		ObjectMeta: v1.ObjectMeta{Name: "spot-oceancd-controller-token", Namespace: namespace},
 		StringData: map[string]string{
 			"token":   clusterToken.Token,
-			"saasUrl": clusterUrl,
+			"saasUrl": apiClient.ClusterUrl(),
 		},
 	}
 
@@ -4,7 +4,6 @@ Copyright © 2022 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"github.com/spf13/cobra"
 	"path/filepath"
@@ -24,9 +23,9 @@ var (
 		Long:    operatorUpgradeDescription,
 		Example: operatorUpgradeExample,
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			validateToken(context.Background())
-			validateClusterId(context.Background())
-			validateClusterIdExists(context.Background())
+			validateToken(cmd.Context())
+			validateClusterId(cmd.Context(), clusterId)
+			validateClusterIdExists(cmd.Context())
 		},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return validateOperatorInstallFlags(cmd)
@@ -34,17 +33,19 @@ var (
 		Args: func(cmd *cobra.Command, args []string) error {
 			return cobra.NoArgs(cmd, args)
 		},
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			isOperatorInstallCommand = false
 			shouldCreateNamespace = false
 
 			fmt.Printf("Upgrading OceanCD operator manager in cluster %s\n", clusterId)
 
-			if err := runOperatorInstallCmd(context.Background(), cmd); err != nil {
+			if err := runOperatorInstallCmd(cmd.Context(), cmd); err != nil {
 				fmt.Printf("Failed to upgrade operator\n%s\n", err)
+				return newExitError(err)
 			}
 
 			fmt.Printf("Upgrade of OceanCD operator manager finished succesfully.\n")
+			return nil
 		},
 	}
 )
@@ -12,21 +12,22 @@ var (
 	pauseExample          = getRolloutActionExample(pauseShortDescription, oceancd.PauseAction)
 
 	pauseCmd = &cobra.Command{
-		Use:     oceancd.PauseAction + " ROLLOUT_ID",
+		Use:     oceancd.PauseAction + " " + rolloutIdUse,
 		Short:   pauseShortDescription,
 		Long:    pauseDescription,
 		Example: pauseExample,
 		Args: func(cmd *cobra.Command, args []string) error {
 			return validateRolloutActionArgs(cmd, args)
 		},
-		Run: func(_ *cobra.Command, args []string) {
-			runRolloutAction(oceancd.PauseAction, args, "paused")
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRolloutAction(cmd.Context(), oceancd.PauseAction, args, "paused")
 		},
 	}
 )
 
 func init() {
 	rolloutCmd.AddCommand(pauseCmd)
+	addRolloutTargetFlags(pauseCmd)
 
 	// Here you will define your flags and configuration settings.
 
@@ -1,40 +1,202 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/spf13/cobra"
 	"spot-oceancd-cli/pkg/oceancd"
+	"spot-oceancd-cli/pkg/oceancd/builders"
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+	"spot-oceancd-cli/viewcontroller/converter"
+	"strings"
+	"time"
 )
 
+type PromoteOptions struct {
+	ToPhase  string
+	Steps    int
+	Interval time.Duration
+	Timeout  time.Duration
+}
+
 // promoteCmd represents the promote command
 var (
-	promoteDescription      = "Promote one phase to the next"
+	promoteDescription = `Promote one phase to the next.
+With --to-phase or --steps, promote phase after phase until the given phase is reached, waiting for every phase to be promoted before promoting the next one`
 	promoteShortDescription = "Promote a rollout"
-	promoteExample          = getRolloutActionExample(promoteShortDescription, oceancd.PromoteAction)
+	promoteExample          = strings.Join([]string{
+		getRolloutActionExample(promoteShortDescription, oceancd.PromoteAction),
+		fmt.Sprintf("  # %s\n  %s %s", "Promote a rollout up to its third phase", rootCmd.Name(),
+			"rollout promote rol-a78dsds9s --to-phase third-phase"),
+	}, "\n\n")
+	promoteOptions = PromoteOptions{}
 
 	promoteCmd = &cobra.Command{
-		Use:     oceancd.PromoteAction + " ROLLOUT_ID",
+		Use:     oceancd.PromoteAction + " " + rolloutIdUse + " [--to-phase PHASE_NAME | --steps N]",
 		Short:   promoteShortDescription,
 		Long:    promoteDescription,
 		Example: promoteExample,
 		Args: func(cmd *cobra.Command, args []string) error {
-			return validateRolloutActionArgs(cmd, args)
+			if err := validateRolloutActionArgs(cmd, args); err != nil {
+				return err
+			}
+
+			return validatePromoteTarget()
 		},
-		Run: func(_ *cobra.Command, args []string) {
-			runRolloutAction(oceancd.PromoteAction, args, "promoted")
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if promoteOptions.ToPhase == "" && promoteOptions.Steps == 0 {
+				return runRolloutAction(cmd.Context(), oceancd.PromoteAction, args, "promoted")
+			}
+
+			return runPromoteToPhaseCmd(cmd.Context(), args)
 		},
 	}
 )
 
 func init() {
 	rolloutCmd.AddCommand(promoteCmd)
+	addRolloutTargetFlags(promoteCmd)
+
+	promoteCmd.Flags().StringVar(&promoteOptions.ToPhase, "to-phase", "", "Promote phase after phase until the given phase is reached")
+	promoteCmd.Flags().IntVar(&promoteOptions.Steps, "steps", 0, "Promote the given number of phases, one after the other")
+	promoteCmd.Flags().DurationVar(&promoteOptions.Interval, "interval", 5*time.Second, "The interval between rollout status checks while waiting for a phase to be promoted")
+	promoteCmd.Flags().DurationVar(&promoteOptions.Timeout, "timeout", 0, "Give up promoting after the given duration, e.g. 30m (default no timeout)")
+}
+
+func validatePromoteTarget() error {
+	if promoteOptions.ToPhase != "" && promoteOptions.Steps != 0 {
+		fmt.Println("You can specify either --to-phase or --steps, not both.")
+		return newUsageError(errors.New("error: Both --to-phase and --steps specified"))
+	}
+
+	if promoteOptions.Steps < 0 {
+		fmt.Printf("Invalid steps %d. The steps must be positive\n", promoteOptions.Steps)
+		return newUsageError(fmt.Errorf("error: Invalid steps %d", promoteOptions.Steps))
+	}
+
+	return nil
+}
+
+func runPromoteToPhaseCmd(ctx context.Context, args []string) error {
+	rolloutId, err := resolveRolloutId(ctx, args, false)
+	if err != nil {
+		return err
+	}
+
+	if promoteOptions.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, promoteOptions.Timeout)
+		defer cancel()
+	}
+
+	detailedRollout, err := builders.NewDetailedRolloutBuilder(rolloutRepository).WithStrategy().Build(ctx, rolloutId)
+	if err != nil {
+		fmt.Printf("Failed to get the rollout %s: %s\n", rolloutId, err.Error())
+		return newExitError(err)
+	}
+
+	var stepNames []string
+	if detailedRollout.Definition.Strategy != nil {
+		stepNames = detailedRollout.Definition.Strategy.GetStepNames()
+	}
+
+	// the strategy does not change while promoting, so the phases are polled without it
+	builder := builders.NewDetailedRolloutBuilder(rolloutRepository)
+
+	phases, err := rollout.PlanPromotion(detailedRollout, stepNames, promoteOptions.ToPhase, promoteOptions.Steps)
+	if err != nil {
+		fmt.Printf("Failed to promote the rollout %s - %s\n", rolloutId, strings.TrimPrefix(err.Error(), "error: "))
+		return &ExitError{Code: ExitCodeUsage, Err: err}
+	}
+
+	for i, phaseName := range phases {
+		fmt.Printf("Promoting phase %s (%d/%d)\n", phaseName, i+1, len(phases))
 
-	// Here you will define your flags and configuration settings.
+		actionRequest := map[string]string{"action": oceancd.PromoteAction}
+		if err = rolloutRepository.SendRolloutAction(ctx, rolloutId, actionRequest); err != nil {
+			fmt.Printf("Failed to %s the rollout %s: %s\n", oceancd.PromoteAction, rolloutId, err.Error())
+			return newExitError(err)
+		}
 
-	// Cobra supports Persistent Flags which will work for this command
-	// and all subcommands, e.g.:
-	// explainCmd.PersistentFlags().String("foo", "", "A help for foo")
+		if err = waitForPhasePromoted(ctx, builder, rolloutId, phaseName); err != nil {
+			return err
+		}
+
+		fmt.Printf("%s Phase %s promoted\n", time.Now().Format(time.RFC3339), phaseName)
+	}
+
+	fmt.Printf("Successfully promoted resource %s to phase %s\n", rolloutId, nextPhaseName(stepNames, phases))
+
+	return nil
+}
+
+// waitForPhasePromoted polls the rollout until the given phase is promoted, so the next promote action
+// applies to the following phase
+func waitForPhasePromoted(ctx context.Context, builder *builders.DetailedRolloutBuilder, rolloutId string, phaseName string) error {
+	interval := promoteOptions.Interval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		detailedRollout, err := builder.Build(ctx, rolloutId)
+		if err != nil {
+			if ctx.Err() != nil {
+				return newPromoteInterruptedError(ctx, rolloutId, phaseName)
+			}
+
+			fmt.Printf("Failed to get the rollout %s: %s\n", rolloutId, err.Error())
+			return newExitError(err)
+		}
+
+		if detailedRollout.IsPhasePromoted(phaseName) || detailedRollout.Status == rollout.Finished {
+			return nil
+		}
+
+		if detailedRollout.Status.IsDone() {
+			fmt.Printf("Rollout %s ended with status %s while promoting phase %s\n", rolloutId,
+				converter.RolloutStatus(detailedRollout.Status), phaseName)
+
+			if statusErr := newRolloutStatusExitError(rolloutId, detailedRollout.Status); statusErr != nil {
+				return statusErr
+			}
+
+			return &ExitError{
+				Code: ExitCodeError,
+				Err:  fmt.Errorf("error: Rollout %s completed while promoting phase %s", rolloutId, phaseName),
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return newPromoteInterruptedError(ctx, rolloutId, phaseName)
+		case <-ticker.C:
+		}
+	}
+}
+
+func newPromoteInterruptedError(ctx context.Context, rolloutId string, phaseName string) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		fmt.Printf("Timed out waiting for phase %s of rollout %s to be promoted\n", phaseName, rolloutId)
+	} else {
+		fmt.Printf("Stopped waiting for phase %s of rollout %s to be promoted\n", phaseName, rolloutId)
+	}
+
+	return &ExitError{Code: ExitCodeError, Err: fmt.Errorf("error: Phase %s of rollout %s was not promoted", phaseName, rolloutId)}
+}
+
+// nextPhaseName returns the phase following the last promoted one
+func nextPhaseName(stepNames []string, promotedPhases []string) string {
+	last := promotedPhases[len(promotedPhases)-1]
+	for i := range stepNames {
+		if stepNames[i] == last && i+1 < len(stepNames) {
+			return stepNames[i+1]
+		}
+	}
 
-	// Cobra supports local flags which will only run when this command
-	// is called directly, e.g.:
-	// explainCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	return last
 }
@@ -12,21 +12,22 @@ var (
 	promoteFullExample          = getRolloutActionExample(promoteFullShortDescription, oceancd.PromoteFullAction)
 
 	promoteFullCmd = &cobra.Command{
-		Use:     oceancd.PromoteFullAction + " ROLLOUT_ID",
+		Use:     oceancd.PromoteFullAction + " " + rolloutIdUse,
 		Short:   promoteFullShortDescription,
 		Long:    promoteFullDescription,
 		Example: promoteFullExample,
 		Args: func(cmd *cobra.Command, args []string) error {
 			return validateRolloutActionArgs(cmd, args)
 		},
-		Run: func(_ *cobra.Command, args []string) {
-			runRolloutAction(oceancd.PromoteFullAction, args, "fully promoted")
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRolloutAction(cmd.Context(), oceancd.PromoteFullAction, args, "fully promoted")
 		},
 	}
 )
 
 func init() {
 	rolloutCmd.AddCommand(promoteFullCmd)
+	addRolloutTargetFlags(promoteFullCmd)
 
 	// Here you will define your flags and configuration settings.
 
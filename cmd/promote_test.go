@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"spot-oceancd-cli/pkg/oceancd"
+	"spot-oceancd-cli/pkg/oceancd/model/phase"
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+	"spot-oceancd-cli/pkg/oceancd/repositories/repositoriestest"
+	"testing"
+)
+
+// promotingRolloutRepository finishes the active phase of rol-eu on every promote action, as the operator would
+type promotingRolloutRepository struct {
+	*repositoriestest.FakeRolloutRepository
+}
+
+func (r *promotingRolloutRepository) SendRolloutAction(ctx context.Context, rolloutId string, body map[string]string) error {
+	if err := r.FakeRolloutRepository.SendRolloutAction(ctx, rolloutId, body); err != nil {
+		return err
+	}
+
+	r.PutRollout(rollout.DetailedRollout{
+		Rollout: rollout.Rollout{Id: "rol-eu", Status: rollout.InProgress, SpotDeployment: "checkout", ClusterId: "prod-eu", Namespace: "shop"},
+		Phases:  []phase.Phase{{Name: "first", Status: phase.Finished}, {Name: "second", Status: phase.InProgress}},
+	}, testRolloutDefinition)
+
+	return nil
+}
+
+func TestPromoteToPhaseCommand(t *testing.T) {
+	cases := map[string]struct {
+		args             []string
+		failWith         string
+		expectedActions  []repositoriestest.FakeRolloutAction
+		expectedExitCode int
+	}{
+		"one step": {
+			args:            []string{"--steps", "1"},
+			expectedActions: []repositoriestest.FakeRolloutAction{{RolloutId: "rol-eu", Action: oceancd.PromoteAction}},
+		},
+		"to the next phase": {
+			args:            []string{"--to-phase", "second"},
+			expectedActions: []repositoriestest.FakeRolloutAction{{RolloutId: "rol-eu", Action: oceancd.PromoteAction}},
+		},
+		"phase already reached": {
+			args:             []string{"--to-phase", "first"},
+			expectedExitCode: ExitCodeUsage,
+		},
+		"beyond the last phase": {
+			args:             []string{"--steps", "2"},
+			expectedExitCode: ExitCodeUsage,
+		},
+		"strategy not fetched": {
+			args:             []string{"--steps", "1"},
+			failWith:         "GetStrategy",
+			expectedExitCode: ExitCodeError,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			repository := &promotingRolloutRepository{FakeRolloutRepository: newFakeRolloutRepository()}
+			if tc.failWith != "" {
+				repository.FailWith(tc.failWith, errors.New("error: Service unavailable"))
+			}
+
+			promoteOptions = PromoteOptions{}
+			args := append([]string{"rollout", oceancd.PromoteAction, "rol-eu", "--interval", "1ms"}, tc.args...)
+			err := executeCommand(t, repository, args...)
+
+			var exitErr *ExitError
+			if tc.expectedExitCode == 0 && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			} else if tc.expectedExitCode != 0 && (errors.As(err, &exitErr) == false || exitErr.Code != tc.expectedExitCode) {
+				t.Fatalf("expected exit code %d, got %v", tc.expectedExitCode, err)
+			}
+
+			if diff := cmp.Diff(tc.expectedActions, repository.Actions(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("actions mismatch (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"os"
+	"spot-oceancd-cli/pkg/oceancd/manifests"
+	"spot-oceancd-cli/pkg/utils"
+)
+
+type PruneOptions struct {
+	Prune  bool
+	Kinds  []string
+	Prefix string
+	Yes    bool
+}
+
+const (
+	pruneFlagLabel       = "prune"
+	pruneKindsFlagLabel  = "prune-kinds"
+	prunePrefixFlagLabel = "prune-prefix"
+)
+
+var pruneOptions = PruneOptions{}
+
+func addPruneFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&pruneOptions.Prune, pruneFlagLabel, false,
+		"Delete the live resources absent from the applied manifests, limited by --prune-kinds and --prune-prefix")
+	cmd.Flags().StringSliceVar(&pruneOptions.Kinds, pruneKindsFlagLabel, nil,
+		"Comma separated kinds of the resources to prune, e.g. strategy,rolloutSpec,verificationTemplate,verificationProvider")
+	cmd.Flags().StringVar(&pruneOptions.Prefix, prunePrefixFlagLabel, "",
+		`Only prune the resources with a name starting with this prefix, an explicit "" prunes any name`)
+	cmd.Flags().BoolVarP(&pruneOptions.Yes, "yes", "y", false, "Prune without asking for confirmation")
+}
+
+// validatePruneFlags requires both the kinds and the name prefix scoping the prune, as the Ocean CD resources
+// carry no annotations to tell which ones the manifests own
+func validatePruneFlags(cmd *cobra.Command) error {
+	if pruneOptions.Prune == false {
+		if cmd.Flags().Changed(pruneKindsFlagLabel) || cmd.Flags().Changed(prunePrefixFlagLabel) {
+			fmt.Printf("The --%s and --%s flags require --%s\n", pruneKindsFlagLabel, prunePrefixFlagLabel, pruneFlagLabel)
+			return newUsageError(errors.New("error: Prune not requested"))
+		}
+
+		return nil
+	}
+
+	if len(pruneOptions.Kinds) == 0 {
+		fmt.Printf("You must specify the kinds of the resources to prune using --%s\n", pruneKindsFlagLabel)
+		return newUsageError(errors.New("error: Required prune kinds not specified"))
+	}
+
+	for i, kind := range pruneOptions.Kinds {
+		entityType, err := utils.GetOceanCdEntityKindByName(kind)
+		if err != nil {
+			fmt.Printf("Unknown resource '%s'. Use \"oceancd api-resources\" for a complete list of supported resources.\n", kind)
+			return newUsageError(err)
+		}
+
+		pruneOptions.Kinds[i] = entityType
+	}
+
+	if cmd.Flags().Changed(prunePrefixFlagLabel) == false {
+		fmt.Printf("You must specify the name prefix of the resources to prune using --%s, --%s=\"\" prunes any name\n",
+			prunePrefixFlagLabel, prunePrefixFlagLabel)
+		return newUsageError(errors.New("error: Required prune prefix not specified"))
+	}
+
+	return nil
+}
+
+// listPruneCandidates lists the live resources of the prune kinds, and returns the ones absent from the applied resources
+func listPruneCandidates(ctx context.Context, applied []*manifests.Resource) ([]*manifests.Resource, error) {
+	var live []*manifests.Resource
+	listed := map[string]bool{}
+
+	for _, entityType := range pruneOptions.Kinds {
+		if listed[entityType] {
+			continue
+		}
+
+		items, err := apiClient.ListEntities(ctx, entityType)
+		if err != nil {
+			return nil, err
+		}
+
+		listed[entityType] = true
+		live = append(live, manifests.NewLiveResources(entityType, items)...)
+	}
+
+	return manifests.PruneCandidates(applied, live, pruneOptions.Prefix), nil
+}
+
+// confirmPrune lists the resources to prune, and asks for a confirmation unless given --yes or running dry
+func confirmPrune(candidates []*manifests.Resource) error {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	fmt.Printf("The following %d %s absent from the manifests will be pruned:\n",
+		len(candidates), utils.GetNounForm("resource", len(candidates)))
+	for _, candidate := range candidates {
+		fmt.Printf("  %s\n", candidate.Key())
+	}
+	fmt.Println()
+
+	if pruneOptions.Yes || isDryRun() {
+		return nil
+	}
+
+	if term.IsTerminal(int(os.Stdin.Fd())) == false {
+		fmt.Println("No terminal to confirm the prune on. Use --yes to prune without confirmation.")
+		return &ExitError{Code: ExitCodeUsage, Err: errors.New("error: Prune confirmation not possible")}
+	}
+
+	confirmed := false
+	prompt := &survey.Confirm{Message: fmt.Sprintf("Apply the manifests and prune %d %s?",
+		len(candidates), utils.GetNounForm("resource", len(candidates)))}
+	if err := survey.AskOne(prompt, &confirmed); err != nil {
+		return err
+	}
+
+	if confirmed == false {
+		fmt.Println("Nothing was applied or pruned")
+		return &ExitError{Code: ExitCodeError, Err: errors.New("error: Prune not confirmed")}
+	}
+
+	return nil
+}
+
+// runApplyWithPruneCmd applies the manifests, then deletes the resources absent from them, dependents first.
+// Nothing is pruned unless every resource was applied.
+func runApplyWithPruneCmd(ctx context.Context) error {
+	resources, file, err := loadResources(ctx)
+	if err != nil {
+		return failedFileError("apply", file, err)
+	}
+
+	if _, err = manifests.NewGraph(resources); err != nil {
+		fmt.Printf("Failed to apply resources - %s\n", err.Error())
+		return newExitError(err)
+	}
+
+	candidates, err := listPruneCandidates(ctx, resources)
+	if err != nil {
+		fmt.Printf("Failed to list the resources to prune - %s\n", err.Error())
+		return newExitError(err)
+	}
+
+	if err = confirmPrune(candidates); err != nil {
+		return err
+	}
+
+	if err = handleResources(ctx, "apply", resources, false, applyResource); err != nil {
+		fmt.Println("Skipped the prune, as not every resource was applied")
+		return err
+	}
+
+	if len(candidates) == 0 {
+		fmt.Println("No resources to prune")
+		return nil
+	}
+
+	fmt.Println()
+	return handleResources(ctx, "prune", candidates, true, deleteResource)
+}
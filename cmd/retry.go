@@ -12,21 +12,22 @@ var (
 	retryExample          = getRolloutActionExample(retryShortDescription, oceancd.RetryAction)
 
 	retryCmd = &cobra.Command{
-		Use:     oceancd.RetryAction + " ROLLOUT_ID ",
+		Use:     oceancd.RetryAction + " " + rolloutIdUse,
 		Short:   retryShortDescription,
 		Long:    retryDescription,
 		Example: retryExample,
 		Args: func(cmd *cobra.Command, args []string) error {
 			return validateRolloutActionArgs(cmd, args)
 		},
-		Run: func(_ *cobra.Command, args []string) {
-			runRolloutAction(oceancd.RetryAction, args, "retried")
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRolloutAction(cmd.Context(), oceancd.RetryAction, args, "retried")
 		},
 	}
 )
 
 func init() {
 	rolloutCmd.AddCommand(retryCmd)
+	addRolloutTargetFlags(retryCmd)
 
 	// Here you will define your flags and configuration settings.
 
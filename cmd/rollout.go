@@ -8,24 +8,34 @@ import (
 	"os"
 	"regexp"
 	"spot-oceancd-cli/pkg/oceancd"
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
 	"strings"
+	"time"
 )
 
+type RolloutTargetOptions struct {
+	SpotDeployment string
+	ClusterId      string
+	Namespace      string
+}
+
 // retryCmd represents the retry command
 var (
 	rolloutUse         = "rollout"
 	rolloutDescription = "This command consists of multiple subcommands which can perform changes on a SpotDeployment rollout"
 
+	rolloutIdUse                 = "(ROLLOUT_ID | --spot-deployment NAME)"
 	rolloutIdExample             = "rol-a78dsds9s"
+	rolloutTargetOptions         = RolloutTargetOptions{}
 	rolloutActionExampleTemplate = "  # %s\n  %s %s %s %s"
 
 	rolloutCmd = &cobra.Command{
 		Use:     rolloutUse,
 		Short:   rolloutDescription,
 		Long:    rolloutDescription,
-		Example: strings.Join([]string{rolloutGetExample, abortExample, pauseExample, promoteExample, promoteFullExample, retryExample}, "\n\n"),
+		Example: strings.Join([]string{rolloutGetExample, rolloutListExample, rolloutHistoryExample, rolloutWaitExample, rolloutMetricsExample, rolloutReplayExample, rolloutReportExample, abortExample, pauseExample, promoteExample, promoteFullExample, retryExample}, "\n\n"),
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			validateToken(context.Background())
+			validateToken(cmd.Context())
 		},
 		Run: func(cmd *cobra.Command, args []string) {
 			err := cmd.Help()
@@ -50,36 +60,62 @@ func init() {
 	// explainCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
 
-func runRolloutAction(action string, args []string, actionPastForm string) {
-	rolloutId := args[0]
+func runRolloutAction(ctx context.Context, action string, args []string, actionPastForm string) error {
+	rolloutId, err := resolveRolloutId(ctx, args, false)
+	if err != nil {
+		return err
+	}
+
 	actionRequest := map[string]string{"action": action}
 
-	err := oceancd.SendRolloutAction(rolloutId, actionRequest)
+	err = rolloutRepository.SendRolloutAction(ctx, rolloutId, actionRequest)
 	if err != nil {
 		fmt.Printf("Failed to %s the rollout %s: %s\n", action, rolloutId, err.Error())
-	} else {
-		fmt.Printf("Successfully %s resource %s\n", actionPastForm, rolloutId)
+		return newExitError(err)
 	}
+
+	fmt.Printf("Successfully %s resource %s\n", actionPastForm, rolloutId)
+
+	return nil
+}
+
+func addRolloutTargetFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&rolloutTargetOptions.SpotDeployment, "spot-deployment", "",
+		"Target the latest active rollout of the given SpotDeployment instead of a rollout id")
+	cmd.Flags().StringVar(&rolloutTargetOptions.ClusterId, ClusterIdFlagLabel, "", "The cluster id within which the SpotDeployment is found")
+	cmd.Flags().StringVar(&rolloutTargetOptions.Namespace, NamespaceFlagLabel, "", "The namespace within which the SpotDeployment is found")
 }
 
 func validateRolloutActionArgs(_ *cobra.Command, args []string) error {
+	if rolloutTargetOptions.SpotDeployment != "" {
+		if len(args) > 0 {
+			fmt.Println("You can specify either a rollout id or a SpotDeployment name, not both.")
+			return newUsageError(errors.New("error: Both rollout id and SpotDeployment name specified"))
+		}
+
+		return nil
+	}
+
 	if len(args) < 1 {
-		fmt.Println("You must specify a rollout id.")
-		return errors.New("error: Rollout id not specified")
+		fmt.Println("You must specify a rollout id or a SpotDeployment name using --spot-deployment.")
+		return newUsageError(errors.New("error: Rollout id not specified"))
 	} else if len(args) > 1 {
 		fmt.Println("You can only specify one rollout id.")
-		return errors.New(fmt.Sprintf("error: Too many arguments: %+v", args))
+		return newUsageError(fmt.Errorf("error: Too many arguments: %+v", args))
 	} else {
-		rolloutId := args[0]
-		if strings.HasPrefix(rolloutId, "rol-") == false {
-			fmt.Printf(`%s is not a valid rollout id`, rolloutId)
-			return errors.New(fmt.Sprintf("error: Invalid rollout id: %s", rolloutId))
-		}
+		return validateRolloutId(args[0])
+	}
+}
 
-		if false == regexp.MustCompile(`^[a-zA-Z\d]*$`).MatchString(strings.TrimPrefix(rolloutId, "rol-")) {
-			fmt.Printf(`%s is not a valid rollout id`, rolloutId)
-			return errors.New(fmt.Sprintf("error: Invalid rollout id: %s", rolloutId))
-		}
+func validateRolloutId(rolloutId string) error {
+	if strings.HasPrefix(rolloutId, "rol-") == false {
+		fmt.Printf("%s is not a valid rollout id\n", rolloutId)
+		return newUsageError(fmt.Errorf("error: Invalid rollout id: %s", rolloutId))
+	}
+
+	if false == regexp.MustCompile(`^[a-zA-Z\d]*$`).MatchString(strings.TrimPrefix(rolloutId, "rol-")) {
+		fmt.Printf("%s is not a valid rollout id\n", rolloutId)
+		return newUsageError(fmt.Errorf("error: Invalid rollout id: %s", rolloutId))
 	}
 
 	return nil
@@ -88,3 +124,138 @@ func validateRolloutActionArgs(_ *cobra.Command, args []string) error {
 func getRolloutActionExample(description string, action string) string {
 	return fmt.Sprintf(rolloutActionExampleTemplate, description, rootCmd.Name(), rolloutUse, action, rolloutIdExample)
 }
+
+// resolveRolloutId returns the rollout id argument, or the id of the latest active rollout of the SpotDeployment
+// given by --spot-deployment. When allowCompleted is set and no rollout is active, the latest rollout is used.
+func resolveRolloutId(ctx context.Context, args []string, allowCompleted bool) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+
+	targetClusterId, targetNamespace := resolveTargetScope(rolloutTargetOptions.ClusterId, rolloutTargetOptions.Namespace)
+	validateClusterId(ctx, targetClusterId)
+	validateNamespace(ctx, targetNamespace)
+
+	spotDeployment := rolloutTargetOptions.SpotDeployment
+	filter := oceancd.RolloutFilter{
+		ClusterId:      targetClusterId,
+		Namespace:      targetNamespace,
+		SpotDeployment: spotDeployment,
+	}
+
+	rollouts, err := rolloutRepository.ListRollouts(ctx, filter)
+	if err != nil {
+		fmt.Printf("Failed to find the rollouts of SpotDeployment %s: %s\n", spotDeployment, err.Error())
+		return "", newExitError(err)
+	}
+
+	latestRollout := findLatestRollout(rollouts, true)
+	if latestRollout == nil && allowCompleted {
+		latestRollout = findLatestRollout(rollouts, false)
+	}
+
+	if latestRollout == nil {
+		fmt.Printf("No active rollout found for SpotDeployment %s in cluster %s, namespace %s\n", spotDeployment, targetClusterId, targetNamespace)
+		return "", &ExitError{
+			Code: ExitCodeNotFound,
+			Err:  fmt.Errorf("error: No active rollout found for SpotDeployment %s", spotDeployment),
+		}
+	}
+
+	return latestRollout.Id, nil
+}
+
+// resolveRolloutIdsBySelector returns the latest active rollout of every SpotDeployment found in the given clusters,
+// narrowed down by --namespace and --spot-deployment. When --spot-deployment is set, its latest finished rollout
+// is used for clusters where it is not rolling out.
+func resolveRolloutIdsBySelector(ctx context.Context, clusters []string) ([]string, error) {
+	spotDeployment := rolloutTargetOptions.SpotDeployment
+	rolloutsBySpotDeployment := map[string][]rollout.Rollout{}
+	var spotDeployments []string
+
+	for _, cluster := range clusters {
+		filter := oceancd.RolloutFilter{
+			ClusterId:      cluster,
+			Namespace:      rolloutTargetOptions.Namespace,
+			SpotDeployment: spotDeployment,
+		}
+
+		rollouts, err := rolloutRepository.ListRollouts(ctx, filter)
+		if err != nil {
+			fmt.Printf("Failed to list the rollouts of cluster %s: %s\n", cluster, err.Error())
+			return nil, newExitError(err)
+		}
+
+		for _, rolloutItem := range rollouts {
+			key := fmt.Sprintf("%s/%s/%s", rolloutItem.ClusterId, rolloutItem.Namespace, rolloutItem.SpotDeployment)
+			if _, ok := rolloutsBySpotDeployment[key]; ok == false {
+				spotDeployments = append(spotDeployments, key)
+			}
+
+			rolloutsBySpotDeployment[key] = append(rolloutsBySpotDeployment[key], rolloutItem)
+		}
+	}
+
+	var retVal []string
+	for _, key := range spotDeployments {
+		latestRollout := findLatestRollout(rolloutsBySpotDeployment[key], true)
+		if latestRollout == nil && spotDeployment != "" {
+			latestRollout = findLatestRollout(rolloutsBySpotDeployment[key], false)
+		}
+
+		if latestRollout != nil {
+			retVal = append(retVal, latestRollout.Id)
+		}
+	}
+
+	if len(retVal) == 0 {
+		fmt.Printf("No rollout found in clusters %s\n", strings.Join(clusters, ", "))
+		return nil, &ExitError{
+			Code: ExitCodeNotFound,
+			Err:  fmt.Errorf("error: No rollout found in clusters %s", strings.Join(clusters, ", ")),
+		}
+	}
+
+	return retVal, nil
+}
+
+// resolveTargetScope returns the configured cluster id and namespace, unless overridden by the given flag values
+func resolveTargetScope(flagClusterId string, flagNamespace string) (string, string) {
+	targetClusterId, targetNamespace := clusterId, namespace
+
+	if flagClusterId != "" {
+		targetClusterId = flagClusterId
+	}
+
+	if flagNamespace != "" {
+		targetNamespace = flagNamespace
+	}
+
+	return targetClusterId, targetNamespace
+}
+
+func findLatestRollout(rollouts []rollout.Rollout, activeOnly bool) *rollout.Rollout {
+	var retVal *rollout.Rollout
+
+	for i := range rollouts {
+		if activeOnly && (rollouts[i].Status.IsCompleted() || rollouts[i].Status == rollout.InvalidSpec) {
+			continue
+		}
+
+		if retVal == nil || isStartedAfter(rollouts[i], *retVal) {
+			retVal = &rollouts[i]
+		}
+	}
+
+	return retVal
+}
+
+func isStartedAfter(a rollout.Rollout, b rollout.Rollout) bool {
+	aStartTime, aErr := time.Parse(time.RFC3339, a.StartTime)
+	bStartTime, bErr := time.Parse(time.RFC3339, b.StartTime)
+	if aErr != nil || bErr != nil {
+		return a.StartTime > b.StartTime
+	}
+
+	return aStartTime.After(bStartTime)
+}
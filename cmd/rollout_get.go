@@ -2,9 +2,10 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/spf13/cobra"
-	"spot-oceancd-cli/pkg/utils"
+	"spot-oceancd-cli/pkg/oceancd/repositories"
 	"spot-oceancd-cli/viewcontroller"
 	"strings"
 	"sync"
@@ -15,6 +16,13 @@ type GetOptions struct {
 	Watch          bool
 	NoColor        bool
 	TimeoutSeconds int
+	Output         string
+	Details        bool
+	Clusters       []string
+	Stacked        bool
+	FailFast       bool
+	Interval       time.Duration
+	Record         string
 }
 
 //  rolloutGetCmd represents the get command
@@ -26,22 +34,44 @@ var (
 
 	rolloutGetWatchExample = fmt.Sprintf("  # %s\n  %s %s\n",
 		"Watch statuses of your running rollouts", rootCmd.Name(), "rollout get example_rollout -w")
+
+	rolloutGetJsonWatchExample = fmt.Sprintf("  # %s\n  %s %s\n",
+		"Stream every change of a rollout as newline delimited json", rootCmd.Name(), "rollout get example_rollout -o json -w")
+
+	rolloutGetBySpotDeploymentExample = fmt.Sprintf("  # %s\n  %s %s\n",
+		"Get the latest rollout of a SpotDeployment", rootCmd.Name(),
+		"rollout get --spot-deployment example --clusterId example-cluster --namespace default")
+
+	rolloutGetRecordExample = fmt.Sprintf("  # %s\n  %s %s\n",
+		"Record every change of a rollout to replay it later", rootCmd.Name(), "rollout get example_rollout -w --record session.jsonl")
+
+	rolloutGetMultipleExample = fmt.Sprintf("  # %s\n  %s %s\n",
+		"Watch a SpotDeployment rolling out in several clusters until all of them complete, stopping on the first failure",
+		rootCmd.Name(), "rollout get --spot-deployment example --cluster prod-eu,prod-us -w --fail-fast")
 	rolloutGetOptions = GetOptions{}
 
 	rolloutGetCmd = &cobra.Command{
-		Use:     "get SPOTDEPLOYMENT_NAME",
-		Short:   rolloutGetShortDescription,
-		Long:    rolloutGetDescription,
-		Example: strings.Join([]string{rolloutGetExample, rolloutGetWatchExample}, "\n\n"),
-		Args:    cobra.ExactArgs(1),
-		Run: func(_ *cobra.Command, args []string) {
-			runRolloutGetAction(args)
+		Use:   "get (ROLLOUT_ID... | --spot-deployment NAME | --cluster CLUSTER_ID...)",
+		Short: rolloutGetShortDescription,
+		Long:  rolloutGetDescription,
+		Example: strings.Join([]string{rolloutGetExample, rolloutGetWatchExample, rolloutGetJsonWatchExample,
+			rolloutGetRecordExample, rolloutGetBySpotDeploymentExample, rolloutGetMultipleExample}, "\n\n"),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := validateRolloutGetArgs(cmd, args); err != nil {
+				return err
+			}
+
+			return validateRolloutGetOutput()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRolloutGetAction(cmd.Context(), args)
 		},
 	}
 )
 
 func init() {
 	rolloutCmd.AddCommand(rolloutGetCmd)
+	addRolloutTargetFlags(rolloutGetCmd)
 
 	// Here you will define your flags and configuration settings.
 
@@ -55,29 +85,73 @@ func init() {
 	rolloutGetCmd.Flags().BoolVarP(&rolloutGetOptions.Watch, "watch", "w", false, "Watch live updates to the rollout")
 	rolloutGetCmd.Flags().BoolVar(&rolloutGetOptions.NoColor, "no-color", false, "Do not colorize output")
 	rolloutGetCmd.Flags().IntVarP(&rolloutGetOptions.TimeoutSeconds, "timeout-seconds", "t", 0, "Timeout after specified seconds")
+	rolloutGetCmd.Flags().DurationVar(&rolloutGetOptions.Interval, "interval", viewcontroller.DefaultWatchInterval,
+		"The interval between rollout refreshes while watching, growing while the rollout does not change")
+	rolloutGetCmd.Flags().StringVarP(&rolloutGetOptions.Output, "output", "o", "",
+		"Output format. One of: json|yaml. With --watch every change is printed as a separate document")
+	rolloutGetCmd.Flags().BoolVar(&rolloutGetOptions.Details, "details", false,
+		"Show the query, failure condition and measured data points of every verification")
+	rolloutGetCmd.Flags().StringSliceVar(&rolloutGetOptions.Clusters, "cluster", nil,
+		"Get the latest active rollouts of the given clusters, narrowed down by --namespace and --spot-deployment")
+	rolloutGetCmd.Flags().BoolVar(&rolloutGetOptions.Stacked, "stacked", false,
+		"With multiple rollouts, show the phases of every rollout instead of a single summary line")
+	rolloutGetCmd.Flags().BoolVar(&rolloutGetOptions.FailFast, "fail-fast", false,
+		"With multiple rollouts, stop watching as soon as one of them fails, is aborted or has an invalid spec")
+	rolloutGetCmd.Flags().StringVar(&rolloutGetOptions.Record, "record", "",
+		"Record every distinct state of the rollout to the given file, to be played back with \"rollout replay\"")
 }
 
 // This code was copied with adjustments from
 // https://github.com/argoproj/argo-rollouts/blob/a6dbe0ec2db3f02cf695ba3c972db72cecabaefb/pkg/kubectl-argo-rollouts/cmd/get/get_rollout.go#L42
-func runRolloutGetAction(args []string) {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	utils.SetupSignalHandler(cancel)
+func runRolloutGetAction(ctx context.Context, args []string) error {
+	if len(rolloutGetOptions.Clusters) > 0 || len(args) > 1 {
+		return runMultiRolloutGetAction(ctx, args)
+	}
 
-	rolloutId := args[0]
-	controller := viewcontroller.NewRolloutViewController(rolloutId, rolloutGetOptions.NoColor)
+	rolloutId, err := resolveRolloutId(ctx, args, true)
+	if err != nil {
+		return err
+	}
 
-	detailedRollout, err := controller.GetRollout()
+	controller := viewcontroller.NewRolloutViewController(rolloutRepository, rolloutId, rolloutGetOptions.NoColor).
+		WithOutput(rolloutGetOptions.Output).
+		WithDetails(rolloutGetOptions.Details).
+		WithInterval(rolloutGetOptions.Interval)
+
+	if rolloutGetOptions.Record != "" {
+		recorder, err := repositories.NewSessionRecorder(rolloutGetOptions.Record)
+		if err != nil {
+			fmt.Printf("%s\n", err)
+			return newExitError(err)
+		}
+		defer recorder.Close()
+
+		controller.WithRecorder(recorder)
+	}
+
+	detailedRollout, err := controller.GetRollout(ctx)
 	if err != nil {
 		fmt.Printf("%s\n", err)
-		return
+		return newExitError(err)
 	}
 
-	controller.PrintRollout(detailedRollout)
+	// the watch only renders the rollout again once it changes
+	controller.WithObservedRollout(detailedRollout)
 
-	if rolloutGetOptions.Watch {
+	if rolloutGetOptions.Output != "" {
+		if err = controller.PrintRolloutDocument(detailedRollout, rolloutGetOptions.Watch); err != nil {
+			fmt.Printf("%s\n", err)
+			return newExitError(err)
+		}
+	} else {
+		controller.PrintRollout(detailedRollout)
+	}
+
+	// the watch stops once the rollout is done, so there is nothing to watch for a done one
+	if rolloutGetOptions.Watch && detailedRollout.Status.IsDone() == false {
 
 		if rolloutGetOptions.TimeoutSeconds > 0 {
+			var cancel context.CancelFunc
 			ts := time.Duration(rolloutGetOptions.TimeoutSeconds)
 			ctx, cancel = context.WithTimeout(ctx, ts*time.Second)
 			defer cancel()
@@ -90,5 +164,113 @@ func runRolloutGetAction(args []string) {
 		go controller.Run(ctx, wg)
 
 		wg.Wait()
+
+		if lastRollout := controller.LastObservedRollout(); lastRollout != nil {
+			detailedRollout = lastRollout
+		}
+	}
+
+	return newRolloutStatusExitError(rolloutId, detailedRollout.Status)
+}
+
+// runMultiRolloutGetAction watches the rollouts until all of them are done, the same way a single rollout is
+// watched until it is done
+func runMultiRolloutGetAction(ctx context.Context, args []string) error {
+	rolloutIds := args
+	if len(rolloutGetOptions.Clusters) > 0 {
+		var err error
+		rolloutIds, err = resolveRolloutIdsBySelector(ctx, rolloutGetOptions.Clusters)
+		if err != nil {
+			return err
+		}
 	}
+
+	controller := viewcontroller.NewMultiRolloutViewController(rolloutRepository, rolloutIds, rolloutGetOptions.NoColor).
+		WithStacked(rolloutGetOptions.Stacked).
+		WithDetails(rolloutGetOptions.Details).
+		WithOutput(rolloutGetOptions.Output).
+		WithFailFast(rolloutGetOptions.FailFast).
+		WithInterval(rolloutGetOptions.Interval)
+
+	if err := controller.Fetch(ctx); err != nil {
+		fmt.Printf("%s\n", err)
+		return newExitError(err)
+	}
+
+	if rolloutGetOptions.Watch {
+		if rolloutGetOptions.TimeoutSeconds > 0 {
+			var cancel context.CancelFunc
+			ts := time.Duration(rolloutGetOptions.TimeoutSeconds)
+			ctx, cancel = context.WithTimeout(ctx, ts*time.Second)
+			defer cancel()
+		}
+
+		controller.Run(ctx)
+	} else {
+		controller.Print()
+	}
+
+	for _, detailedRollout := range controller.Rollouts() {
+		if detailedRollout == nil {
+			continue
+		}
+
+		if statusErr := newRolloutStatusExitError(detailedRollout.Id, detailedRollout.Status); statusErr != nil {
+			return statusErr
+		}
+	}
+
+	return nil
+}
+
+func validateRolloutGetArgs(cmd *cobra.Command, args []string) error {
+	if len(rolloutGetOptions.Clusters) > 0 {
+		if len(args) > 0 {
+			fmt.Println("You can specify either rollout ids or clusters to get the rollouts of, not both.")
+			return newUsageError(errors.New("error: Both rollout ids and clusters specified"))
+		}
+
+		if rolloutTargetOptions.ClusterId != "" {
+			fmt.Printf("You can specify either --%s or --cluster, not both.\n", ClusterIdFlagLabel)
+			return newUsageError(fmt.Errorf("error: Both --%s and --cluster specified", ClusterIdFlagLabel))
+		}
+
+		return validateRolloutGetRecord()
+	}
+
+	if len(args) > 1 && rolloutTargetOptions.SpotDeployment == "" {
+		if err := validateRolloutGetRecord(); err != nil {
+			return err
+		}
+
+		for _, rolloutId := range args {
+			if err := validateRolloutId(rolloutId); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return validateRolloutActionArgs(cmd, args)
+}
+
+func validateRolloutGetOutput() error {
+	switch rolloutGetOptions.Output {
+	case "", "json", "yaml", "yml":
+		return nil
+	default:
+		fmt.Printf("Unknown output '%s'. Please choose one of: json|yaml\n", rolloutGetOptions.Output)
+		return newUsageError(fmt.Errorf("error: Unknown output %s", rolloutGetOptions.Output))
+	}
+}
+
+// validateRolloutGetRecord rejects --record for multiple rollouts, since a session is the recording of a single rollout
+func validateRolloutGetRecord() error {
+	if rolloutGetOptions.Record == "" {
+		return nil
+	}
+
+	fmt.Println("You can only record a single rollout.")
+	return newUsageError(errors.New("error: --record specified with multiple rollouts"))
 }
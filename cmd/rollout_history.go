@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"sort"
+	"spot-oceancd-cli/pkg/oceancd"
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+	"spot-oceancd-cli/pkg/utils"
+)
+
+type HistoryOptions struct {
+	SpotDeployment string
+	ClusterId      string
+	Namespace      string
+	Limit          int
+	Output         string
+}
+
+// rolloutHistoryCmd represents the rollout history command
+var (
+	rolloutHistoryDescription = `List the past rollouts of a SpotDeployment, newest first, with their versions, strategy, final status and duration.
+Retried rollouts are linked to their retries through their retry chain`
+	rolloutHistoryShortDescription = "List the past rollouts of a SpotDeployment"
+	rolloutHistoryExample          = fmt.Sprintf("  # %s\n  %s %s",
+		"List the last 10 rollouts of a SpotDeployment", rootCmd.Name(),
+		"rollout history --spot-deployment example --clusterId example-cluster --namespace default --limit 10")
+	rolloutHistoryOptions = HistoryOptions{}
+
+	rolloutHistoryCmd = &cobra.Command{
+		Use:     "history --spot-deployment NAME [(-o|--output=)json|yaml|yml|wide]",
+		Short:   rolloutHistoryShortDescription,
+		Long:    rolloutHistoryDescription,
+		Example: rolloutHistoryExample,
+		Args:    validateRolloutHistoryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRolloutHistoryCmd(cmd.Context())
+		},
+	}
+)
+
+func init() {
+	rolloutCmd.AddCommand(rolloutHistoryCmd)
+
+	rolloutHistoryCmd.Flags().StringVar(&rolloutHistoryOptions.SpotDeployment, "spot-deployment", "", "The SpotDeployment to list the rollouts of")
+	rolloutHistoryCmd.Flags().StringVar(&rolloutHistoryOptions.ClusterId, ClusterIdFlagLabel, "", "The cluster id within which the SpotDeployment is found")
+	rolloutHistoryCmd.Flags().StringVar(&rolloutHistoryOptions.Namespace, NamespaceFlagLabel, "", "The namespace within which the SpotDeployment is found")
+	rolloutHistoryCmd.Flags().IntVar(&rolloutHistoryOptions.Limit, "limit", 0, "Only list the given number of latest rollouts, 0 lists all of them")
+	rolloutHistoryCmd.Flags().StringVarP(&rolloutHistoryOptions.Output, "output", "o", "wide", "Output format. One of: json|yaml|wide")
+}
+
+func validateRolloutHistoryArgs(_ *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		fmt.Println("The history command takes no arguments, use --spot-deployment to specify the SpotDeployment.")
+		return newUsageError(errors.New("error: Unexpected arguments"))
+	}
+
+	if rolloutHistoryOptions.SpotDeployment == "" {
+		fmt.Println("You must specify a SpotDeployment name using --spot-deployment.")
+		return newUsageError(errors.New("error: SpotDeployment name not specified"))
+	}
+
+	if rolloutHistoryOptions.Limit < 0 {
+		fmt.Printf("Invalid limit %d. The limit must not be negative\n", rolloutHistoryOptions.Limit)
+		return newUsageError(fmt.Errorf("error: Invalid limit %d", rolloutHistoryOptions.Limit))
+	}
+
+	return nil
+}
+
+func runRolloutHistoryCmd(ctx context.Context) error {
+	targetClusterId, targetNamespace := resolveTargetScope(rolloutHistoryOptions.ClusterId, rolloutHistoryOptions.Namespace)
+	validateClusterId(ctx, targetClusterId)
+	validateNamespace(ctx, targetNamespace)
+
+	spotDeployment := rolloutHistoryOptions.SpotDeployment
+	filter := oceancd.RolloutFilter{
+		ClusterId:      targetClusterId,
+		Namespace:      targetNamespace,
+		SpotDeployment: spotDeployment,
+	}
+
+	rollouts, err := rolloutRepository.ListRollouts(ctx, filter)
+	if err != nil {
+		fmt.Printf("Failed to list the rollouts of SpotDeployment %s - %s\n", spotDeployment, err.Error())
+		return newExitError(err)
+	}
+
+	sort.SliceStable(rollouts, func(i, j int) bool {
+		return isStartedAfter(rollouts[i], rollouts[j])
+	})
+
+	// the retry chains are linked through all the rollouts, also the ones beyond the limit
+	history := rollout.NewHistory(rollouts)
+	if rolloutHistoryOptions.Limit > 0 && len(history) > rolloutHistoryOptions.Limit {
+		history = history[:rolloutHistoryOptions.Limit]
+	}
+
+	resources := make([]interface{}, len(history))
+	for i := range history {
+		resources[i] = history[i]
+	}
+
+	switch rolloutHistoryOptions.Output {
+	case "yaml", "yml":
+		resourcesStr, yamlErr := utils.ConvertEntitiesToYamlString(resources)
+		if yamlErr != nil {
+			fmt.Printf("Failed to convert rollout history to yaml - %s\n", yamlErr.Error())
+			return newExitError(yamlErr)
+		}
+		fmt.Println(resourcesStr)
+	case "json":
+		resourcesStr, jsonErr := utils.ConvertEntitiesToJsonString(resources)
+		if jsonErr != nil {
+			fmt.Printf("Failed to convert rollout history to json - %s\n", jsonErr.Error())
+			return newExitError(jsonErr)
+		}
+		fmt.Println(resourcesStr)
+	case "wide":
+		if len(history) == 0 {
+			fmt.Printf("No rollouts found for SpotDeployment %s in cluster %s, namespace %s\n", spotDeployment, targetClusterId, targetNamespace)
+			return nil
+		}
+
+		details := make([]rollout.HistoryDetails, len(history))
+		for i := range history {
+			details[i] = rollout.ConvertToHistoryDetails(history[i])
+		}
+
+		printer := newTablePrinter()
+		printer.Print(details)
+		fmt.Println(historySummary(history))
+	default:
+		fmt.Printf("Unknown output '%s'. Please choose one of: json|yaml|wide\n", rolloutHistoryOptions.Output)
+		return &ExitError{Code: ExitCodeUsage, Err: fmt.Errorf("error: Unknown output %s", rolloutHistoryOptions.Output)}
+	}
+
+	return nil
+}
+
+// historySummary counts how many of the completed rollouts were unsuccessful
+func historySummary(history []rollout.HistoryEntry) string {
+	completed, unsuccessful := 0, 0
+	for _, entry := range history {
+		if entry.Status.IsDone() {
+			completed++
+		}
+
+		if entry.Status.IsUnsuccessful() {
+			unsuccessful++
+		}
+	}
+
+	rollouts := fmt.Sprintf("%d %s", len(history), utils.GetNounForm("rollout", len(history)))
+	if completed == 0 {
+		return fmt.Sprintf("%s, none completed", rollouts)
+	}
+
+	return fmt.Sprintf("%s, %d completed, %d unsuccessful (%d%%)", rollouts, completed, unsuccessful, unsuccessful*100/completed)
+}
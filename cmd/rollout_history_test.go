@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+	"testing"
+)
+
+func TestHistorySummary(t *testing.T) {
+	cases := map[string]struct {
+		statuses []rollout.Status
+		expected string
+	}{
+		"no rollouts":   {expected: "0 rollouts, none completed"},
+		"one rollout":   {statuses: []rollout.Status{rollout.InProgress}, expected: "1 rollout, none completed"},
+		"one completed": {statuses: []rollout.Status{rollout.Finished}, expected: "1 rollout, 1 completed, 0 unsuccessful (0%)"},
+		"several rollouts": {
+			statuses: []rollout.Status{rollout.InProgress, rollout.Failed, rollout.Finished, rollout.Aborted},
+			expected: "4 rollouts, 3 completed, 2 unsuccessful (66%)",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			history := make([]rollout.HistoryEntry, len(tc.statuses))
+			for i, status := range tc.statuses {
+				history[i] = rollout.HistoryEntry{Status: status}
+			}
+
+			if actual := historySummary(history); actual != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/spf13/cobra"
+	"spot-oceancd-cli/pkg/oceancd"
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+	"spot-oceancd-cli/pkg/utils"
+	"strings"
+	"sync"
+	"time"
+)
+
+const rolloutListPhasesConcurrency = 5
+
+type ListOptions struct {
+	ClusterId      string
+	Namespace      string
+	SpotDeployment string
+	Statuses       []string
+	Since          time.Duration
+	Output         string
+}
+
+// rolloutListCmd represents the rollout list command
+var (
+	rolloutListDescription      = "List OceanCD rollouts, optionally filtered by cluster, namespace, SpotDeployment, status and start time"
+	rolloutListShortDescription = "List OceanCD rollouts"
+	rolloutListExample          = fmt.Sprintf("  # %s\n  %s %s\n\n  # %s\n  %s %s",
+		"List the failed rollouts of the last day", rootCmd.Name(), "rollout list --status failed --since 24h",
+		"List the rollouts of a SpotDeployment in JSON output format", rootCmd.Name(),
+		"rollout list --cluster example-cluster --namespace default --spot-deployment example -o json")
+	rolloutListOptions = ListOptions{}
+
+	rolloutListCmd = &cobra.Command{
+		Use:     "list [(-o|--output=)json|yaml|yml|wide]",
+		Aliases: []string{"ls"},
+		Short:   rolloutListShortDescription,
+		Long:    rolloutListDescription,
+		Example: rolloutListExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRolloutListCmd(cmd.Context())
+		},
+	}
+)
+
+func init() {
+	rolloutCmd.AddCommand(rolloutListCmd)
+
+	rolloutListCmd.Flags().StringVar(&rolloutListOptions.ClusterId, "cluster", "", "Only list rollouts of the given cluster id")
+	rolloutListCmd.Flags().StringVar(&rolloutListOptions.Namespace, "namespace", "", "Only list rollouts of the given namespace")
+	rolloutListCmd.Flags().StringVar(&rolloutListOptions.SpotDeployment, "spot-deployment", "", "Only list rollouts of the given SpotDeployment")
+	rolloutListCmd.Flags().StringSliceVar(&rolloutListOptions.Statuses, "status", nil, "Only list rollouts in the given statuses, e.g. inProgress,failed")
+	rolloutListCmd.Flags().DurationVar(&rolloutListOptions.Since, "since", 0, "Only list rollouts started within the given duration, e.g. 24h")
+	rolloutListCmd.Flags().StringVarP(&rolloutListOptions.Output, "output", "o", "wide", "Output format. One of: json|yaml|wide")
+}
+
+func runRolloutListCmd(ctx context.Context) error {
+	filter := oceancd.RolloutFilter{
+		ClusterId:      rolloutListOptions.ClusterId,
+		Namespace:      rolloutListOptions.Namespace,
+		SpotDeployment: rolloutListOptions.SpotDeployment,
+	}
+
+	for _, status := range rolloutListOptions.Statuses {
+		filter.Statuses = append(filter.Statuses, rollout.Status(strings.TrimSpace(status)))
+	}
+
+	if rolloutListOptions.Since > 0 {
+		filter.Since = time.Now().Add(-rolloutListOptions.Since)
+	}
+
+	rollouts, err := rolloutRepository.ListRollouts(ctx, filter)
+	if err != nil {
+		fmt.Printf("Failed to list rollouts - %s\n", err.Error())
+		return newExitError(err)
+	}
+
+	resources := make([]interface{}, len(rollouts))
+	for i := range rollouts {
+		resources[i] = rollouts[i]
+	}
+
+	switch rolloutListOptions.Output {
+	case "yaml", "yml":
+		resourcesStr, yamlErr := utils.ConvertEntitiesToYamlString(resources)
+		if yamlErr != nil {
+			fmt.Printf("Failed to convert rollouts to yaml - %s\n", yamlErr.Error())
+			return newExitError(yamlErr)
+		}
+		fmt.Println(resourcesStr)
+	case "json":
+		resourcesStr, jsonErr := utils.ConvertEntitiesToJsonString(resources)
+		if jsonErr != nil {
+			fmt.Printf("Failed to convert rollouts to json - %s\n", jsonErr.Error())
+			return newExitError(jsonErr)
+		}
+		fmt.Println(resourcesStr)
+	case "wide":
+		if len(rollouts) == 0 {
+			fmt.Println("No rollouts found")
+			return nil
+		}
+
+		printer := newTablePrinter()
+		printer.Print(getRolloutsDetails(ctx, rollouts))
+	default:
+		fmt.Printf("Unknown output '%s'. Please choose one of: json|yaml|wide\n", rolloutListOptions.Output)
+		return &ExitError{Code: ExitCodeUsage, Err: fmt.Errorf("error: Unknown output %s", rolloutListOptions.Output)}
+	}
+
+	return nil
+}
+
+// getRolloutsDetails fetches the phases of every rollout to show its progress.
+// A rollout whose phases can't be fetched is still listed, without progress.
+func getRolloutsDetails(ctx context.Context, rollouts []rollout.Rollout) []rollout.RolloutDetails {
+	retVal := make([]rollout.RolloutDetails, len(rollouts))
+	semaphore := make(chan struct{}, rolloutListPhasesConcurrency)
+	wg := sync.WaitGroup{}
+
+	for i := range rollouts {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			detailedRollout := rollout.DetailedRollout{Rollout: rollouts[i]}
+			if phases, err := rolloutRepository.GetRolloutPhases(ctx, rollouts[i].Id); err == nil {
+				detailedRollout.Phases = phases
+			}
+
+			retVal[i] = rollout.ConvertToRolloutDetails(detailedRollout)
+		}(i)
+	}
+
+	wg.Wait()
+
+	return retVal
+}
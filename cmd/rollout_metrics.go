@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/spf13/cobra"
+	"spot-oceancd-cli/viewcontroller"
+	"strings"
+	"sync"
+	"time"
+)
+
+type MetricsOptions struct {
+	Metric         string
+	Watch          bool
+	NoColor        bool
+	Chart          bool
+	Height         int
+	TimeoutSeconds int
+	Interval       time.Duration
+}
+
+// rolloutMetricsCmd represents the rollout metrics command
+var (
+	rolloutMetricsDescription = `Render the data points measured by the verifications of a rollout as sparklines or line charts.
+The failure condition threshold is drawn over the chart and the failed data points are highlighted`
+	rolloutMetricsShortDescription = "Chart the verification data points of a rollout"
+	rolloutMetricsExample          = fmt.Sprintf("  # %s\n  %s %s",
+		"Chart every verification of a rollout", rootCmd.Name(), "rollout metrics rol-a78dsds9s")
+
+	rolloutMetricsWatchExample = fmt.Sprintf("  # %s\n  %s %s\n",
+		"Watch the data points of a single metric as a line chart", rootCmd.Name(), "rollout metrics rol-a78dsds9s --metric error-rate --chart -w")
+	rolloutMetricsOptions = MetricsOptions{}
+
+	rolloutMetricsCmd = &cobra.Command{
+		Use:     "metrics " + rolloutIdUse,
+		Short:   rolloutMetricsShortDescription,
+		Long:    rolloutMetricsDescription,
+		Example: strings.Join([]string{rolloutMetricsExample, rolloutMetricsWatchExample}, "\n\n"),
+		Args:    validateRolloutActionArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRolloutMetricsAction(cmd.Context(), args)
+		},
+	}
+)
+
+func init() {
+	rolloutCmd.AddCommand(rolloutMetricsCmd)
+	addRolloutTargetFlags(rolloutMetricsCmd)
+
+	rolloutMetricsCmd.Flags().StringVar(&rolloutMetricsOptions.Metric, "metric", "", "Only chart the verifications of the given metric name")
+	rolloutMetricsCmd.Flags().BoolVarP(&rolloutMetricsOptions.Watch, "watch", "w", false, "Watch live updates and append new data points")
+	rolloutMetricsCmd.Flags().BoolVar(&rolloutMetricsOptions.NoColor, "no-color", false, "Do not colorize output")
+	rolloutMetricsCmd.Flags().BoolVar(&rolloutMetricsOptions.Chart, "chart", false, "Render line charts instead of sparklines")
+	rolloutMetricsCmd.Flags().IntVar(&rolloutMetricsOptions.Height, "height", 8, "The number of rows of a line chart")
+	rolloutMetricsCmd.Flags().IntVarP(&rolloutMetricsOptions.TimeoutSeconds, "timeout-seconds", "t", 0, "Timeout after specified seconds")
+	rolloutMetricsCmd.Flags().DurationVar(&rolloutMetricsOptions.Interval, "interval", viewcontroller.DefaultWatchInterval,
+		"The interval between rollout refreshes while watching, growing while the rollout does not change")
+}
+
+func runRolloutMetricsAction(ctx context.Context, args []string) error {
+	rolloutId, err := resolveRolloutId(ctx, args, true)
+	if err != nil {
+		return err
+	}
+
+	chartHeight := 0
+	if rolloutMetricsOptions.Chart {
+		chartHeight = rolloutMetricsOptions.Height
+	}
+
+	controller := viewcontroller.NewRolloutViewController(rolloutRepository, rolloutId, rolloutMetricsOptions.NoColor).
+		WithMetrics(rolloutMetricsOptions.Metric, chartHeight).
+		WithInterval(rolloutMetricsOptions.Interval)
+
+	detailedRollout, err := controller.GetRollout(ctx)
+	if err != nil {
+		fmt.Printf("%s\n", err)
+		return newExitError(err)
+	}
+
+	// the watch only renders the rollout again once it changes
+	controller.WithObservedRollout(detailedRollout)
+	controller.PrintMetrics(detailedRollout)
+
+	if rolloutMetricsOptions.Watch && detailedRollout.Status.IsDone() == false {
+		if rolloutMetricsOptions.TimeoutSeconds > 0 {
+			var cancel context.CancelFunc
+			ts := time.Duration(rolloutMetricsOptions.TimeoutSeconds)
+			ctx, cancel = context.WithTimeout(ctx, ts*time.Second)
+			defer cancel()
+		}
+
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+
+		go controller.Run(ctx, wg)
+
+		wg.Wait()
+	}
+
+	return nil
+}
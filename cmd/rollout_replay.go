@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"spot-oceancd-cli/pkg/oceancd/repositories"
+	"spot-oceancd-cli/viewcontroller"
+	"strconv"
+	"strings"
+)
+
+type ReplayOptions struct {
+	Speed   string
+	NoColor bool
+	Details bool
+	Output  string
+}
+
+// rolloutReplayCmd represents the rollout replay command
+var (
+	rolloutReplayDescription = `Play back a rollout session recorded with "rollout get --record", exactly as it looked live.
+The recorded changes are shown at the pace they were observed, or faster with --speed`
+	rolloutReplayShortDescription = "Play back a recorded rollout session"
+	rolloutReplayExample          = fmt.Sprintf("  # %s\n  %s %s",
+		"Replay a recorded rollout ten times faster", rootCmd.Name(), "rollout replay session.jsonl --speed 10x")
+	rolloutReplayOptions = ReplayOptions{}
+
+	rolloutReplayCmd = &cobra.Command{
+		Use:     "replay SESSION_FILE",
+		Short:   rolloutReplayShortDescription,
+		Long:    rolloutReplayDescription,
+		Example: rolloutReplayExample,
+		Args:    cobra.ExactArgs(1),
+		// a recorded session is played back without the api, so no token is needed
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRolloutReplayAction(cmd.Context(), args[0])
+		},
+	}
+)
+
+func init() {
+	rolloutCmd.AddCommand(rolloutReplayCmd)
+
+	rolloutReplayCmd.Flags().StringVar(&rolloutReplayOptions.Speed, "speed", "1x", "The playback speed, e.g. 10x plays the session ten times faster")
+	rolloutReplayCmd.Flags().BoolVar(&rolloutReplayOptions.NoColor, "no-color", false, "Do not colorize output")
+	rolloutReplayCmd.Flags().BoolVar(&rolloutReplayOptions.Details, "details", false,
+		"Show the query, failure condition and measured data points of every verification")
+	rolloutReplayCmd.Flags().StringVarP(&rolloutReplayOptions.Output, "output", "o", "",
+		"Output format. One of: json|yaml. Every recorded change is printed as a separate document")
+}
+
+func runRolloutReplayAction(ctx context.Context, sessionFile string) error {
+	speed, err := parseReplaySpeed(rolloutReplayOptions.Speed)
+	if err != nil {
+		fmt.Printf("Invalid speed '%s'. Please specify a positive factor, e.g. 10x\n", rolloutReplayOptions.Speed)
+		return &ExitError{Code: ExitCodeUsage, Err: err}
+	}
+
+	switch rolloutReplayOptions.Output {
+	case "", "json", "yaml", "yml":
+	default:
+		fmt.Printf("Unknown output '%s'. Please choose one of: json|yaml\n", rolloutReplayOptions.Output)
+		return &ExitError{Code: ExitCodeUsage, Err: fmt.Errorf("error: Unknown output %s", rolloutReplayOptions.Output)}
+	}
+
+	snapshots, err := repositories.ReadSession(sessionFile)
+	if err != nil {
+		fmt.Printf("%s\n", err)
+		return newExitError(err)
+	}
+
+	controller := viewcontroller.NewReplayViewController(snapshots, rolloutReplayOptions.NoColor).
+		WithSpeed(speed)
+	controller.WithOutput(rolloutReplayOptions.Output).
+		WithDetails(rolloutReplayOptions.Details)
+
+	if err = controller.Run(ctx); err != nil && errors.Is(err, context.Canceled) == false {
+		fmt.Printf("Failed to replay the session %s - %s\n", sessionFile, err.Error())
+		return newExitError(err)
+	}
+
+	return nil
+}
+
+// parseReplaySpeed accepts a factor with an optional x suffix, e.g. 10x, 0.5x or 2
+func parseReplaySpeed(value string) (float64, error) {
+	speed, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(value), "x"), 64)
+	if err != nil || speed <= 0 {
+		return 0, fmt.Errorf("error: Invalid speed %s", value)
+	}
+
+	return speed, nil
+}
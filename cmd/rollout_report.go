@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+	"spot-oceancd-cli/viewcontroller"
+	"spot-oceancd-cli/viewcontroller/report"
+	"strings"
+)
+
+type ReportOptions struct {
+	Format string
+}
+
+// rolloutReportCmd represents the rollout report command
+var (
+	rolloutReportDescription = `Render the timeline of a rollout as a Markdown or HTML report, to be attached to a change ticket.
+The report holds the times and traffic weight of every phase, the verification outcomes with their data points,
+the header route matches of the strategy, the versions with their replicas and the total duration.
+The same rollout always renders the same report`
+	rolloutReportShortDescription = "Export the timeline of a rollout as a report"
+	rolloutReportExample          = fmt.Sprintf("  # %s\n  %s %s",
+		"Export the report of a rollout as html", rootCmd.Name(), "rollout report rol-a78dsds9s --format html > report.html")
+	rolloutReportOptions = ReportOptions{}
+
+	rolloutReportCmd = &cobra.Command{
+		Use:     "report " + rolloutIdUse,
+		Short:   rolloutReportShortDescription,
+		Long:    rolloutReportDescription,
+		Example: rolloutReportExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := validateRolloutActionArgs(cmd, args); err != nil {
+				return err
+			}
+
+			return validateRolloutReportFormat()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRolloutReportAction(cmd.Context(), args)
+		},
+	}
+)
+
+func init() {
+	rolloutCmd.AddCommand(rolloutReportCmd)
+	addRolloutTargetFlags(rolloutReportCmd)
+
+	rolloutReportCmd.Flags().StringVar(&rolloutReportOptions.Format, "format", report.FormatMarkdown,
+		fmt.Sprintf("Report format. One of: %s", strings.Join(report.Formats, "|")))
+}
+
+func runRolloutReportAction(ctx context.Context, args []string) error {
+	rolloutId, err := resolveRolloutId(ctx, args, true)
+	if err != nil {
+		return err
+	}
+
+	detailedRollout, err := viewcontroller.NewRolloutViewController(rolloutRepository, rolloutId, true).GetRollout(ctx)
+	if err != nil {
+		fmt.Printf("%s\n", err)
+		return newExitError(err)
+	}
+
+	if err = report.New(detailedRollout).Render(os.Stdout, rolloutReportOptions.Format); err != nil {
+		fmt.Printf("Failed to render the report of the rollout %s - %s\n", rolloutId, err.Error())
+		return newExitError(err)
+	}
+
+	return nil
+}
+
+func validateRolloutReportFormat() error {
+	for _, format := range report.Formats {
+		if rolloutReportOptions.Format == format {
+			return nil
+		}
+	}
+
+	fmt.Printf("Unknown format '%s'. Please choose one of: %s\n", rolloutReportOptions.Format, strings.Join(report.Formats, "|"))
+	return newUsageError(fmt.Errorf("error: Unknown format %s", rolloutReportOptions.Format))
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"spot-oceancd-cli/pkg/oceancd"
+	"spot-oceancd-cli/pkg/oceancd/model/phase"
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+	"spot-oceancd-cli/pkg/oceancd/repositories"
+	"spot-oceancd-cli/pkg/oceancd/repositories/repositoriestest"
+	"testing"
+)
+
+var testRolloutDefinition = map[string]interface{}{
+	"strategy": map[string]interface{}{
+		"canary": map[string]interface{}{
+			"steps": []interface{}{map[string]interface{}{"name": "first"}, map[string]interface{}{"name": "second"}},
+		},
+	},
+}
+
+func newFakeRolloutRepository() *repositoriestest.FakeRolloutRepository {
+	repository := repositoriestest.NewFakeRolloutRepository()
+	repository.PutRollout(rollout.DetailedRollout{
+		Rollout: rollout.Rollout{Id: "rol-eu", Status: rollout.InProgress, SpotDeployment: "checkout", ClusterId: "prod-eu", Namespace: "shop"},
+		Phases:  []phase.Phase{{Name: "first", Status: phase.InProgress}},
+	}, testRolloutDefinition)
+	repository.PutRollout(rollout.DetailedRollout{
+		Rollout: rollout.Rollout{Id: "rol-us", Status: rollout.Finished, SpotDeployment: "checkout", ClusterId: "prod-us", Namespace: "shop"},
+		Phases:  []phase.Phase{{Name: "first", Status: phase.Finished}, {Name: "second", Status: phase.Finished}},
+	}, testRolloutDefinition)
+
+	return repository
+}
+
+// executeCommand runs the root command with the given arguments against the repository, away from any local config
+func executeCommand(t *testing.T, repository repositories.RolloutRepository, args ...string) error {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	original := newRolloutRepository
+	newRolloutRepository = func(*oceancd.Client) repositories.RolloutRepository { return repository }
+	defer func() { newRolloutRepository = original }()
+
+	// the commands report their own failures, as in Execute
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+
+	rolloutTargetOptions = RolloutTargetOptions{}
+	rootCmd.SetArgs(append(args, "--token", "test-token"))
+
+	return rootCmd.ExecuteContext(context.Background())
+}
+
+func TestRolloutActionCommands(t *testing.T) {
+	cases := map[string]struct {
+		args             []string
+		expectedActions  []repositoriestest.FakeRolloutAction
+		expectedExitCode int
+	}{
+		"by rollout id": {
+			args:            []string{"rollout", oceancd.PauseAction, "rol-eu"},
+			expectedActions: []repositoriestest.FakeRolloutAction{{RolloutId: "rol-eu", Action: oceancd.PauseAction}},
+		},
+		"by spot deployment": {
+			args: []string{"rollout", oceancd.AbortAction, "--spot-deployment", "checkout",
+				"--" + ClusterIdFlagLabel, "prod-eu", "--" + NamespaceFlagLabel, "shop"},
+			expectedActions: []repositoriestest.FakeRolloutAction{{RolloutId: "rol-eu", Action: oceancd.AbortAction}},
+		},
+		"unknown rollout": {
+			args:             []string{"rollout", oceancd.PauseAction, "rol-missing"},
+			expectedExitCode: ExitCodeNotFound,
+		},
+		"no active rollout of the spot deployment": {
+			args: []string{"rollout", oceancd.AbortAction, "--spot-deployment", "checkout",
+				"--" + ClusterIdFlagLabel, "prod-us", "--" + NamespaceFlagLabel, "shop"},
+			expectedExitCode: ExitCodeNotFound,
+		},
+		"invalid rollout id": {
+			args:             []string{"rollout", oceancd.PauseAction, "rollout-eu"},
+			expectedExitCode: ExitCodeUsage,
+		},
+		"too many rollout ids": {
+			args:             []string{"rollout", oceancd.PauseAction, "rol-eu", "rol-us"},
+			expectedExitCode: ExitCodeUsage,
+		},
+		"both rollout id and spot deployment": {
+			args:             []string{"rollout", oceancd.PauseAction, "rol-eu", "--spot-deployment", "checkout"},
+			expectedExitCode: ExitCodeUsage,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			repository := newFakeRolloutRepository()
+			err := executeCommand(t, repository, tc.args...)
+
+			var exitErr *ExitError
+			if tc.expectedExitCode == 0 && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			} else if tc.expectedExitCode != 0 && (errors.As(err, &exitErr) == false || exitErr.Code != tc.expectedExitCode) {
+				t.Fatalf("expected exit code %d, got %v", tc.expectedExitCode, err)
+			}
+
+			if diff := cmp.Diff(tc.expectedActions, repository.Actions(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("actions mismatch (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveRolloutIdKeepsConfiguredScope(t *testing.T) {
+	err := executeCommand(t, newFakeRolloutRepository(), "rollout", oceancd.PauseAction, "--spot-deployment", "checkout",
+		"--"+ClusterIdFlagLabel, "prod-eu", "--"+NamespaceFlagLabel, "shop")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if clusterId != "" || namespace != "" {
+		t.Errorf("expected the flags not to override the configured scope, got cluster %q, namespace %q", clusterId, namespace)
+	}
+}
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"spot-oceancd-cli/pkg/oceancd/builders"
+	"spot-oceancd-cli/pkg/oceancd/model/phase"
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+	"spot-oceancd-cli/viewcontroller/converter"
+	"strings"
+	"time"
+)
+
+const (
+	waitForCompleted   = "completed"
+	waitForPhasePrefix = "phase:"
+)
+
+type WaitOptions struct {
+	For      string
+	Timeout  time.Duration
+	Interval time.Duration
+}
+
+// waitCondition is satisfied once the rollout reaches the state given by --for
+type waitCondition struct {
+	description string
+	isMet       func(detailedRollout *rollout.DetailedRollout) bool
+}
+
+// rolloutWaitCmd represents the rollout wait command
+var (
+	rolloutWaitDescription = `Wait until a rollout completes or reaches the state given by --for.
+Exits with a non-zero code when the rollout fails, is aborted or has an invalid spec, which makes it usable for CI/CD gating`
+	rolloutWaitShortDescription = "Wait for a rollout to reach a state"
+	rolloutWaitExample          = fmt.Sprintf("  # %s\n  %s %s\n\n  # %s\n  %s %s",
+		"Wait up to 30 minutes for a rollout to complete", rootCmd.Name(), "rollout wait rol-a78dsds9s --timeout 30m",
+		"Wait for the latest rollout of a SpotDeployment to reach a phase", rootCmd.Name(),
+		"rollout wait --spot-deployment example --for phase:second-phase")
+	rolloutWaitOptions = WaitOptions{}
+
+	rolloutWaitCmd = &cobra.Command{
+		Use:     "wait " + rolloutIdUse,
+		Short:   rolloutWaitShortDescription,
+		Long:    rolloutWaitDescription,
+		Example: rolloutWaitExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := validateRolloutActionArgs(cmd, args); err != nil {
+				return err
+			}
+
+			_, err := parseWaitCondition(rolloutWaitOptions.For)
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRolloutWaitCmd(cmd.Context(), args)
+		},
+	}
+)
+
+func init() {
+	rolloutCmd.AddCommand(rolloutWaitCmd)
+	addRolloutTargetFlags(rolloutWaitCmd)
+
+	rolloutWaitCmd.Flags().StringVar(&rolloutWaitOptions.For, "for", waitForCompleted,
+		"The state to wait for. One of: completed|STATUS|phase:PHASE_NAME, e.g. paused, which matches any paused status, or phase:second-phase")
+	rolloutWaitCmd.Flags().DurationVar(&rolloutWaitOptions.Timeout, "timeout", 0, "Give up waiting after the given duration, e.g. 30m (default no timeout)")
+	rolloutWaitCmd.Flags().DurationVar(&rolloutWaitOptions.Interval, "interval", 5*time.Second, "The interval between rollout status checks")
+}
+
+func parseWaitCondition(waitFor string) (*waitCondition, error) {
+	switch {
+	case waitFor == "" || strings.EqualFold(waitFor, waitForCompleted):
+		return &waitCondition{
+			description: waitForCompleted,
+			isMet: func(detailedRollout *rollout.DetailedRollout) bool {
+				return detailedRollout.Status.IsDone()
+			},
+		}, nil
+	case strings.HasPrefix(waitFor, waitForPhasePrefix):
+		phaseName := strings.TrimPrefix(waitFor, waitForPhasePrefix)
+		if phaseName == "" {
+			fmt.Printf("You must specify a phase name, e.g. --for %ssecond-phase\n", waitForPhasePrefix)
+			return nil, newUsageError(errors.New("error: Phase name not specified"))
+		}
+
+		return &waitCondition{
+			description: fmt.Sprintf("phase %s", phaseName),
+			isMet: func(detailedRollout *rollout.DetailedRollout) bool {
+				for _, rolloutPhase := range detailedRollout.Phases {
+					if rolloutPhase.Name == phaseName {
+						return rolloutPhase.Status != "" && rolloutPhase.Status != phase.Pending
+					}
+				}
+
+				return false
+			},
+		}, nil
+	default:
+		status, ok := rollout.ParseStatus(waitFor)
+		if ok == false {
+			fmt.Printf("Unknown rollout status '%s'. Please choose one of: %s\n", waitFor, joinStatuses(rollout.Statuses))
+			return nil, newUsageError(fmt.Errorf("error: Unknown rollout status %s", waitFor))
+		}
+
+		// paused stands for any paused status, as the rollout is rather paused manually or by its failure policy
+		if status == rollout.Paused {
+			return &waitCondition{
+				description: string(status),
+				isMet: func(detailedRollout *rollout.DetailedRollout) bool {
+					return detailedRollout.Status.IsPaused()
+				},
+			}, nil
+		}
+
+		return &waitCondition{
+			description: string(status),
+			isMet: func(detailedRollout *rollout.DetailedRollout) bool {
+				return detailedRollout.Status == status
+			},
+		}, nil
+	}
+}
+
+func runRolloutWaitCmd(ctx context.Context, args []string) error {
+	condition, err := parseWaitCondition(rolloutWaitOptions.For)
+	if err != nil {
+		return err
+	}
+
+	rolloutId, err := resolveRolloutId(ctx, args, true)
+	if err != nil {
+		return err
+	}
+
+	if rolloutWaitOptions.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, rolloutWaitOptions.Timeout)
+		defer cancel()
+	}
+
+	interval := rolloutWaitOptions.Interval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	builder := builders.NewDetailedRolloutBuilder(rolloutRepository)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	fmt.Printf("Waiting for rollout %s to reach %s\n", rolloutId, condition.description)
+
+	lastProgress := ""
+	for {
+		detailedRollout, err := builder.Build(ctx, rolloutId)
+		if err != nil {
+			if ctx.Err() != nil {
+				return newWaitInterruptedError(ctx, rolloutId, condition)
+			}
+
+			fmt.Printf("Failed to get the rollout %s: %s\n", rolloutId, err.Error())
+			return newExitError(err)
+		}
+
+		if progress := getWaitProgress(detailedRollout); progress != lastProgress {
+			fmt.Printf("%s %s\n", time.Now().Format(time.RFC3339), progress)
+			lastProgress = progress
+		}
+
+		if condition.isMet(detailedRollout) {
+			if statusErr := newRolloutStatusExitError(rolloutId, detailedRollout.Status); statusErr != nil &&
+				strings.EqualFold(rolloutWaitOptions.For, string(detailedRollout.Status)) == false {
+				fmt.Printf("Rollout %s ended with status %s\n", rolloutId, converter.RolloutStatus(detailedRollout.Status))
+				return statusErr
+			}
+
+			fmt.Printf("Rollout %s reached %s\n", rolloutId, condition.description)
+			return nil
+		}
+
+		if detailedRollout.Status.IsDone() {
+			fmt.Printf("Rollout %s ended with status %s without reaching %s\n", rolloutId,
+				converter.RolloutStatus(detailedRollout.Status), condition.description)
+
+			if statusErr := newRolloutStatusExitError(rolloutId, detailedRollout.Status); statusErr != nil {
+				return statusErr
+			}
+
+			return &ExitError{
+				Code: ExitCodeError,
+				Err:  fmt.Errorf("error: Rollout %s completed without reaching %s", rolloutId, condition.description),
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return newWaitInterruptedError(ctx, rolloutId, condition)
+		case <-ticker.C:
+		}
+	}
+}
+
+// getWaitProgress builds a single log friendly line out of the rollout status and its active phase
+func getWaitProgress(detailedRollout *rollout.DetailedRollout) string {
+	progress := fmt.Sprintf("Rollout %s status: %s", detailedRollout.Id, converter.RolloutStatus(detailedRollout.Status))
+
+	if len(detailedRollout.Phases) > 0 {
+		progress = fmt.Sprintf("%s, phases: %s", progress, detailedRollout.PhaseProgress())
+
+		activePhase := detailedRollout.Phases[detailedRollout.ActivePhase()-1]
+		if activePhase.Name != "" && detailedRollout.Status.IsCompleted() == false {
+			progress = fmt.Sprintf("%s (%s %s)", progress, activePhase.Name, converter.PhaseStatus(activePhase))
+		}
+	}
+
+	return progress
+}
+
+func newWaitInterruptedError(ctx context.Context, rolloutId string, condition *waitCondition) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		fmt.Printf("Timed out waiting for rollout %s to reach %s\n", rolloutId, condition.description)
+	} else {
+		fmt.Printf("Stopped waiting for rollout %s to reach %s\n", rolloutId, condition.description)
+	}
+
+	return &ExitError{Code: ExitCodeError, Err: fmt.Errorf("error: Rollout %s did not reach %s", rolloutId, condition.description)}
+}
+
+func joinStatuses(statuses []rollout.Status) string {
+	retVal := make([]string, len(statuses))
+	for i, status := range statuses {
+		retVal[i] = string(status)
+	}
+
+	return strings.Join(retVal, "|")
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"errors"
+	"spot-oceancd-cli/pkg/oceancd/model/phase"
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+	"testing"
+)
+
+func TestParseWaitCondition(t *testing.T) {
+	cases := map[string]struct {
+		waitFor  string
+		status   rollout.Status
+		phases   []phase.Phase
+		expected bool
+	}{
+		"completed by default":          {waitFor: "", status: rollout.Finished, expected: true},
+		"not completed":                 {waitFor: waitForCompleted, status: rollout.InProgress, expected: false},
+		"completed with invalid spec":   {waitFor: waitForCompleted, status: rollout.InvalidSpec, expected: true},
+		"paused":                        {waitFor: "paused", status: rollout.Paused, expected: true},
+		"paused matches manual pause":   {waitFor: "paused", status: rollout.ManualPaused, expected: true},
+		"paused matches failure policy": {waitFor: "Paused", status: rollout.FailurePolicyPaused, expected: true},
+		"paused does not match pausing": {waitFor: "paused", status: rollout.ManualPausing, expected: false},
+		"exact status":                  {waitFor: "manualPaused", status: rollout.FailurePolicyPaused, expected: false},
+		"status":                        {waitFor: "verifying", status: rollout.Verifying, expected: true},
+		"phase reached": {
+			waitFor:  waitForPhasePrefix + "second",
+			phases:   []phase.Phase{{Name: "first", Status: phase.Finished}, {Name: "second", Status: phase.InProgress}},
+			expected: true,
+		},
+		"phase pending": {
+			waitFor: waitForPhasePrefix + "second",
+			phases:  []phase.Phase{{Name: "first", Status: phase.InProgress}, {Name: "second", Status: phase.Pending}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			condition, err := parseWaitCondition(tc.waitFor)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			detailedRollout := &rollout.DetailedRollout{Rollout: rollout.Rollout{Status: tc.status}, Phases: tc.phases}
+			if actual := condition.isMet(detailedRollout); actual != tc.expected {
+				t.Errorf("expected the condition met %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseWaitConditionInvalid(t *testing.T) {
+	for _, waitFor := range []string{"sleeping", waitForPhasePrefix} {
+		_, err := parseWaitCondition(waitFor)
+
+		var exitErr *ExitError
+		if errors.As(err, &exitErr) == false || exitErr.Code != ExitCodeUsage {
+			t.Errorf("%s: expected a usage error, got %v", waitFor, err)
+		}
+	}
+}
@@ -2,12 +2,16 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/spf13/viper"
 	"os"
 	"path/filepath"
 	"spot-oceancd-cli/pkg/oceancd"
 	"spot-oceancd-cli/pkg/oceancd/model"
+	"spot-oceancd-cli/pkg/oceancd/repositories"
+	"spot-oceancd-cli/pkg/utils"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -22,10 +26,19 @@ For more information visit our github repo https://github.com/spotinst/spot-ocea
 	clusterUrl            string
 	clusterId             string
 	namespace             string
+	requestTimeout        time.Duration
+	maxRetries            int
+	retryWait             time.Duration
+	verbose               bool
 	isTokenFromConfig     = false
 	isProfileOverriden    = false
 	isClusterIdOverridden = false
 	isNamespaceOverridden = false
+	apiClient             *oceancd.Client
+	rolloutRepository     repositories.RolloutRepository
+
+	// newRolloutRepository builds the repository of the rollout commands, tests swap it for a fake one
+	newRolloutRepository = repositories.NewRolloutRepository
 
 	rootCmd = &cobra.Command{
 		Use:   "oceancd",
@@ -41,9 +54,29 @@ For more information visit our github repo https://github.com/spotinst/spot-ocea
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	err := rootCmd.Execute()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	utils.SetupSignalHandler(cancel)
+
+	// Commands report their own failures, see ExitError
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+
+	cmd, err := rootCmd.ExecuteContextC(ctx)
+
+	if verbose {
+		printRetriedRequests()
+	}
+
 	if err != nil {
-		os.Exit(1)
+		var exitErr *ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
+
+		// Anything else was rejected by cobra or by the argument validation before the command ran
+		fmt.Fprintf(os.Stderr, "Error: %s\nRun '%s --help' for usage.\n", err.Error(), cmd.CommandPath())
+		os.Exit(ExitCodeUsage)
 	}
 }
 
@@ -62,6 +95,13 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&token, "token", "", "unqiue spot token for api authentication")
 	rootCmd.PersistentFlags().StringVar(&url, "url", "", "Base ocean cd api url")
 	rootCmd.PersistentFlags().StringVar(&clusterUrl, "clusterUrl", "", "Base ocean cd cluster api url")
+	rootCmd.PersistentFlags().DurationVar(&requestTimeout, "request-timeout", 0,
+		"The length of time to wait before giving up on a single api request (e.g. 30s, 1m). Zero means no timeout")
+	rootCmd.PersistentFlags().IntVar(&maxRetries, "max-retries", oceancd.DefaultMaxRetries,
+		"Number of times to retry a failed api request on transient errors. Zero disables retries")
+	rootCmd.PersistentFlags().DurationVar(&retryWait, "retry-wait", oceancd.DefaultRetryWaitTime,
+		"Initial wait before retrying a failed api request. The wait grows exponentially or follows the Retry-After header")
+	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Print additional diagnostic details")
 	_ = viper.BindPFlag("token", rootCmd.PersistentFlags().Lookup("token"))
 	_ = viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
 	_ = viper.BindPFlag("url", rootCmd.PersistentFlags().Lookup("url"))
@@ -98,7 +138,7 @@ func initConfig() {
 		url = viper.GetString(urlKey)
 
 		if url == "" {
-			url = "https://api.spotinst.io"
+			url = oceancd.DefaultBaseUrl
 		}
 
 		viper.Set("url", url)
@@ -110,7 +150,7 @@ func initConfig() {
 		clusterUrl = viper.GetString(clusterUrlKey)
 
 		if clusterUrl == "" {
-			clusterUrl = "https://cluster-gateway.oceancd.io"
+			clusterUrl = oceancd.DefaultClusterUrl
 		}
 
 		viper.Set("clusterUrl", clusterUrl)
@@ -132,61 +172,93 @@ func initConfig() {
 		viper.Set("namespace", namespace)
 	}
 
+	apiClient = newApiClient()
+	rolloutRepository = newRolloutRepository(apiClient)
+
 	return
 }
 
+func newApiClient() *oceancd.Client {
+	return oceancd.NewClient(oceancd.ClientOptions{
+		BaseUrl:       url,
+		ClusterUrl:    clusterUrl,
+		Token:         token,
+		UserAgent:     fmt.Sprintf("%s/%s", oceancd.DefaultUserAgent, version),
+		Timeout:       requestTimeout,
+		MaxRetries:    maxRetries,
+		RetryWaitTime: retryWait,
+	})
+}
+
+func printRetriedRequests() {
+	if apiClient == nil {
+		return
+	}
+
+	retriedRequests := apiClient.RetriedRequests()
+	if len(retriedRequests) == 0 {
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, "Retried api requests:")
+	for _, retried := range retriedRequests {
+		fmt.Fprintf(os.Stderr, "  %s %s - %d failed %s, last: %s\n", retried.Method, retried.Url,
+			retried.FailedAttempts, utils.GetNounForm("attempt", retried.FailedAttempts), retried.LastReason)
+	}
+}
+
 func validateToken(_ context.Context) {
 	if token == "" {
 		fmt.Println("You haven't specify your access token. You can use \"oceancd configure\" to create a config file")
-		os.Exit(1)
+		os.Exit(ExitCodeUnauthorized)
 	}
 }
 
-func validateClusterId(_ context.Context) {
+func validateClusterId(_ context.Context, clusterId string) {
 	if clusterId == "" {
 		fmt.Printf(`You haven't specified your cluster ID. You can use "oceancd configure" to configure the 
 missing parameters using the profile variables or use the appropriate flag: --%s`, ClusterIdFlagLabel)
 		fmt.Println("")
-		os.Exit(1)
+		os.Exit(ExitCodeUsage)
 	}
 }
 
-func validateNamespace(_ context.Context) {
+func validateNamespace(_ context.Context, namespace string) {
 	if namespace == "" {
 		fmt.Printf(`You haven't specified your namespace. You can use "oceancd configure" to configure the 
 missing parameters using the profile variables or use the appropriate flag: --%s`, NamespaceFlagLabel)
 		fmt.Println("")
-		os.Exit(1)
+		os.Exit(ExitCodeUsage)
 	}
 }
 
-func validateClusterIdExists(_ context.Context) {
-	resource, err := oceancd.GetEntity(context.Background(), model.ClusterEntity, clusterId)
+func validateClusterIdExists(ctx context.Context) {
+	resource, err := apiClient.GetEntity(ctx, model.ClusterEntity, clusterId)
 	if err != nil {
-		if err.Error() != "resource does not exist" {
+		if oceancd.IsNotFound(err) == false {
 			fmt.Printf("Failed to fetch cluster %s from saas, %s\n", clusterId, err.Error())
-			os.Exit(1)
+			os.Exit(exitCodeOf(err))
 		}
 	}
 
 	if resource == nil {
 		fmt.Printf("Cluster %s does not exists\n", clusterId)
-		os.Exit(1)
+		os.Exit(ExitCodeNotFound)
 	}
 }
 
-func validateClusterIdNotExists(_ context.Context) {
-	resource, err := oceancd.GetEntity(context.Background(), model.ClusterEntity, clusterId)
+func validateClusterIdNotExists(ctx context.Context) {
+	resource, err := apiClient.GetEntity(ctx, model.ClusterEntity, clusterId)
 	if err != nil {
-		if err.Error() != fmt.Sprintf("error: Resource '%s/%s' does not exist", model.ClusterEntity, clusterId) {
+		if oceancd.IsNotFound(err) == false {
 			fmt.Printf("Failed to fetch cluster %s from saas, %s\n", clusterId, err.Error())
-			os.Exit(1)
+			os.Exit(exitCodeOf(err))
 		}
 	}
 
 	if resource != nil {
 		fmt.Printf("Cluster %s allready exists\n", clusterId)
-		os.Exit(1)
+		os.Exit(ExitCodeError)
 	}
 }
 
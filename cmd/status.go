@@ -27,8 +27,8 @@ const (
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Check operator status",
-	Run: func(cmd *cobra.Command, args []string) {
-		runOperatorStatusCmd(context.Background())
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runOperatorStatusCmd(context.Background())
 	},
 }
 
@@ -46,17 +46,17 @@ func init() {
 	// statusCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
 
-func runOperatorStatusCmd(ctx context.Context) {
+func runOperatorStatusCmd(ctx context.Context) error {
 	config, configErr := ctrl.GetConfig()
 	if configErr != nil {
 		fmt.Printf("The connection to the kubernetes server was refused - %s\n", configErr.Error())
-		return
+		return newExitError(configErr)
 	}
 
 	clientset, clientError := kubernetes.NewForConfig(config)
 	if clientError != nil {
-		fmt.Printf("The connection to the kubernetes server was refused - %s\n", configErr.Error())
-		return
+		fmt.Printf("The connection to the kubernetes server was refused - %s\n", clientError.Error())
+		return newExitError(clientError)
 	}
 
 	discoveryClient := cacheddiscovery.NewMemCacheClient(clientset.Discovery())
@@ -65,21 +65,21 @@ func runOperatorStatusCmd(ctx context.Context) {
 
 	if gvkErr != nil {
 		fmt.Printf("The connection to the kubernetes server was refused - %s\n", gvkErr.Error())
-		return
+		return newExitError(gvkErr)
 	}
 
 	restMapping, mappingErr := discoveryRESTMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
 
 	if mappingErr != nil {
 		fmt.Printf("The connection to the kubernetes server was refused - %s\n", mappingErr.Error())
-		return
+		return newExitError(mappingErr)
 	}
 
 	dynamicClient, dynamicErr := dynamic.NewForConfig(config)
 
 	if dynamicErr != nil {
 		fmt.Printf("The connection to the kubernetes server was refused - %s\n", dynamicErr.Error())
-		return
+		return newExitError(dynamicErr)
 	}
 
 	items, listErr := dynamicClient.
@@ -89,22 +89,23 @@ func runOperatorStatusCmd(ctx context.Context) {
 
 	if listErr != nil {
 		fmt.Printf("The connection to the kubernetes server was refused - %s\n", listErr.Error())
-		return
+		return newExitError(listErr)
 	}
 
 	for _, operator := range items.Items {
 		if operator.Object["spec"].(map[string]interface{})["displayName"] == OceanCDOperator {
 			version := operator.Object["spec"].(map[string]interface{})["version"]
 
-			if operator.Object["status"].(map[string]interface{})["phase"] == "Succeeded" {
-				fmt.Printf("%s %s is running\n", OceanCDOperator, version)
-			} else {
+			if operator.Object["status"].(map[string]interface{})["phase"] != "Succeeded" {
 				fmt.Printf("%s %s is not ready\n", OceanCDOperator, version)
+				return &ExitError{Code: ExitCodeError, Err: fmt.Errorf("error: %s %s is not ready", OceanCDOperator, version)}
 			}
 
-			return
+			fmt.Printf("%s %s is running\n", OceanCDOperator, version)
+			return nil
 		}
 	}
 
 	fmt.Printf("%s is not installed\n", OceanCDOperator)
+	return &ExitError{Code: ExitCodeNotFound, Err: fmt.Errorf("error: %s is not installed", OceanCDOperator)}
 }
@@ -6,7 +6,6 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"os"
-	"spot-oceancd-cli/pkg/oceancd"
 	"strings"
 )
 
@@ -23,9 +22,9 @@ var (
 		Long:    workloadDescription,
 		Example: strings.Join([]string{workloadRestartExample, workloadRetryExample, workloadRollbackExample}, "\n\n"),
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			validateToken(context.Background())
-			validateClusterId(context.Background())
-			validateNamespace(context.Background())
+			validateToken(cmd.Context())
+			validateClusterId(cmd.Context(), clusterId)
+			validateNamespace(cmd.Context(), namespace)
 		},
 		Run: func(cmd *cobra.Command, args []string) {
 			err := cmd.Help()
@@ -53,7 +52,7 @@ func init() {
 	_ = viper.BindPFlag("namespace", workloadCmd.PersistentFlags().Lookup(NamespaceFlagLabel))
 }
 
-func runWorkloadAction(action string, args []string, actionPastForm string) {
+func runWorkloadAction(ctx context.Context, action string, args []string, actionPastForm string) error {
 	spotDeploymentName := args[0]
 
 	pathParam := map[string]string{
@@ -71,10 +70,13 @@ func runWorkloadAction(action string, args []string, actionPastForm string) {
 		"kind":      "SpotDeployment",
 	}
 
-	err := oceancd.SendWorkloadAction(pathParam, queryParam)
+	err := apiClient.SendWorkloadAction(ctx, pathParam, queryParam)
 	if err != nil {
 		fmt.Printf("Failed to %s the workload %s: %s\n", action, spotDeploymentName, err.Error())
-	} else {
-		fmt.Printf("Successfully %s workload %s\n", actionPastForm, spotDeploymentName)
+		return newExitError(err)
 	}
+
+	fmt.Printf("Successfully %s workload %s\n", actionPastForm, spotDeploymentName)
+
+	return nil
 }
@@ -26,8 +26,8 @@ var (
 			}
 			return nil
 		},
-		Run: func(_ *cobra.Command, args []string) {
-			runWorkloadAction(oceancd.RestartAction, args, "restarted")
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWorkloadAction(cmd.Context(), oceancd.RestartAction, args, "restarted")
 		},
 	}
 )
@@ -34,8 +34,8 @@ var (
 
 			return nil
 		},
-		Run: func(_ *cobra.Command, args []string) {
-			runWorkloadAction(oceancd.RetryAction, args, "retried")
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWorkloadAction(cmd.Context(), oceancd.RetryAction, args, "retried")
 		},
 	}
 )
@@ -34,8 +34,8 @@ var (
 
 			return nil
 		},
-		Run: func(_ *cobra.Command, args []string) {
-			runWorkloadAction(oceancd.RollbackAction, args, "rolled back")
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWorkloadAction(cmd.Context(), oceancd.RollbackAction, args, "rolled back")
 		},
 	}
 )
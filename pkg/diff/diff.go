@@ -0,0 +1,136 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+type ChangeType string
+
+const (
+	Added    ChangeType = "+"
+	Removed  ChangeType = "-"
+	Modified ChangeType = "~"
+)
+
+// ServerManagedFields are set by the SaaS on every resource, and are never part of a manifest
+var ServerManagedFields = []string{"id", "createdAt", "updatedAt"}
+
+// Change is a difference between the live and the desired resource at the given field path, e.g. spec.steps[1].name
+type Change struct {
+	Type ChangeType
+	Path string
+	Old  interface{}
+	New  interface{}
+}
+
+// Normalize turns a resource, decoded from either json or yaml, into plain json values without its server managed fields
+func Normalize(resource interface{}) (interface{}, error) {
+	resourceBytes, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	var retVal interface{}
+	if err = json.Unmarshal(resourceBytes, &retVal); err != nil {
+		return nil, err
+	}
+
+	if fields, ok := retVal.(map[string]interface{}); ok {
+		for _, field := range ServerManagedFields {
+			delete(fields, field)
+		}
+	}
+
+	return retVal, nil
+}
+
+// Compare lists the changes turning the live resource into the desired one, sorted by path.
+// Only the fields declared by the desired resource are compared, as the live one also carries the defaults
+// filled by the SaaS. Both resources are expected to be normalized.
+func Compare(live interface{}, desired interface{}) []Change {
+	var changes []Change
+	compare("", live, desired, &changes)
+
+	return changes
+}
+
+func compare(path string, live interface{}, desired interface{}, changes *[]Change) {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		liveValue, ok := live.(map[string]interface{})
+		if ok == false {
+			break
+		}
+
+		keys := map[string]bool{}
+		for key := range desiredValue {
+			keys[key] = true
+		}
+
+		for _, key := range sortedKeys(keys) {
+			liveField, isLive := liveValue[key]
+			desiredField := desiredValue[key]
+
+			switch {
+			case isLive == false && desiredField != nil:
+				*changes = append(*changes, Change{Type: Added, Path: join(path, key), New: desiredField})
+			case isLive:
+				compare(join(path, key), liveField, desiredField, changes)
+			}
+		}
+
+		return
+	case []interface{}:
+		liveValue, ok := live.([]interface{})
+		if ok == false {
+			break
+		}
+
+		for i := 0; i < len(liveValue) || i < len(desiredValue); i++ {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+
+			switch {
+			case i >= len(liveValue):
+				*changes = append(*changes, Change{Type: Added, Path: itemPath, New: desiredValue[i]})
+			case i >= len(desiredValue):
+				*changes = append(*changes, Change{Type: Removed, Path: itemPath, Old: liveValue[i]})
+			default:
+				compare(itemPath, liveValue[i], desiredValue[i], changes)
+			}
+		}
+
+		return
+	}
+
+	if equal(live, desired) == false {
+		*changes = append(*changes, Change{Type: Modified, Path: path, Old: live, New: desired})
+	}
+}
+
+func equal(a interface{}, b interface{}) bool {
+	aBytes, aErr := json.Marshal(a)
+	bBytes, bErr := json.Marshal(b)
+
+	return aErr == nil && bErr == nil && string(aBytes) == string(bBytes)
+}
+
+func join(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func sortedKeys(keys map[string]bool) []string {
+	retVal := make([]string, 0, len(keys))
+	for key := range keys {
+		retVal = append(retVal, key)
+	}
+
+	sort.Strings(retVal)
+
+	return retVal
+}
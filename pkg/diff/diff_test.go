@@ -0,0 +1,115 @@
+package diff
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	live := map[string]interface{}{
+		"id":        "vp-123",
+		"createdAt": "2026-10-01T00:00:00Z",
+		"updatedAt": "2026-10-02T00:00:00Z",
+		"name":      "prometheus-provider",
+		"prometheus": map[string]interface{}{
+			"address": "http://prometheus:9090",
+		},
+		"clusterIds": []interface{}{"prod-eu", "prod-us"},
+		"datadog":    nil,
+		"legacy":     true,
+	}
+
+	var desired map[string]interface{}
+	manifest := `
+name: prometheus-provider
+prometheus:
+  address: http://prometheus.monitoring:9090
+clusterIds: [prod-eu, prod-us, prod-ap]
+newRelic:
+  accountId: 42
+`
+	if err := yaml.Unmarshal([]byte(manifest), &desired); err != nil {
+		t.Fatal(err)
+	}
+
+	normalizedLive, err := Normalize(live)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	normalizedDesired, err := Normalize(desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Change{
+		{Type: Added, Path: "clusterIds[2]", New: "prod-ap"},
+		{Type: Added, Path: "newRelic", New: map[string]interface{}{"accountId": float64(42)}},
+		{Type: Modified, Path: "prometheus.address", Old: "http://prometheus:9090", New: "http://prometheus.monitoring:9090"},
+	}
+
+	if diff := cmp.Diff(expected, Compare(normalizedLive, normalizedDesired)); diff != "" {
+		t.Errorf("changes mismatch (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestCompareUnchanged(t *testing.T) {
+	live, _ := Normalize(map[string]interface{}{"id": "st-1", "name": "canary", "steps": []interface{}{map[string]interface{}{"weight": 10}}})
+	desired, _ := Normalize(map[string]interface{}{"name": "canary", "steps": []interface{}{map[string]interface{}{"weight": 10}}})
+
+	if changes := Compare(live, desired); len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+}
+
+func TestCompareServerDefaults(t *testing.T) {
+	live, _ := Normalize(map[string]interface{}{
+		"id":        "st-1",
+		"createdAt": "2026-10-01T00:00:00Z",
+		"name":      "canary",
+		"canary": map[string]interface{}{
+			"backgroundVerification": nil,
+			"steps": []interface{}{
+				map[string]interface{}{"name": "first", "setWeight": 20, "pause": map[string]interface{}{"duration": "2m"}, "verification": nil},
+				map[string]interface{}{"name": "second", "setWeight": 100, "setCanaryScale": map[string]interface{}{"matchTrafficWeight": true}},
+			},
+		},
+		"description": "",
+	})
+
+	var desired map[string]interface{}
+	manifest := `
+name: canary
+canary:
+  steps:
+    - name: first
+      setWeight: 20
+      pause:
+        duration: 2m
+    - name: second
+      setWeight: 100
+`
+	if err := yaml.Unmarshal([]byte(manifest), &desired); err != nil {
+		t.Fatal(err)
+	}
+
+	normalizedDesired, err := Normalize(desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if changes := Compare(live, normalizedDesired); len(changes) != 0 {
+		t.Errorf("expected the server defaults not to be changes, got %v", changes)
+	}
+}
+
+func TestCompareRemovedItem(t *testing.T) {
+	live, _ := Normalize(map[string]interface{}{"clusterIds": []interface{}{"prod-eu", "prod-us"}})
+	desired, _ := Normalize(map[string]interface{}{"clusterIds": []interface{}{"prod-eu"}})
+
+	expected := []Change{{Type: Removed, Path: "clusterIds[1]", Old: "prod-us"}}
+	if diff := cmp.Diff(expected, Compare(live, desired)); diff != "" {
+		t.Errorf("changes mismatch (-expected +actual):\n%s", diff)
+	}
+}
@@ -3,10 +3,8 @@ package oceancd
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"github.com/go-resty/resty/v2"
-	"github.com/spf13/viper"
+	"net/http"
 	"net/url"
 	"spot-oceancd-cli/pkg/oceancd/model/operator"
 	"spot-oceancd-cli/pkg/oceancd/model/phase"
@@ -14,17 +12,11 @@ import (
 	"spot-oceancd-cli/pkg/oceancd/model/verification"
 )
 
-func CreateResource(ctx context.Context, entityType string, resourceToCreate interface{}) error {
-	token := viper.GetString("token")
-	baseUrl := viper.GetString("url")
+func (c *Client) CreateResource(ctx context.Context, entityType string, resourceToCreate interface{}) error {
+	apiPrefixTemplate := "/ocean/cd/%v"
+	apiUrl := fmt.Sprintf(apiPrefixTemplate, entityType)
 
-	client := resty.New()
-	apiPrefixTemplate := "%v/ocean/cd/%v"
-	apiUrl := fmt.Sprintf(apiPrefixTemplate, baseUrl, entityType)
-
-	response, err := client.R().
-		SetAuthToken(token).
-		ForceContentType("application/json").
+	response, err := c.newRequest(ctx).
 		SetBody(resourceToCreate).
 		Post(apiUrl)
 
@@ -33,24 +25,18 @@ func CreateResource(ctx context.Context, entityType string, resourceToCreate int
 	}
 
 	if status := response.StatusCode(); status != 200 {
-		err = parseErrorFromResponse(response.Body())
+		err = parseErrorFromResponse(response)
 		return err
 	}
 
 	return nil
 }
 
-func UpdateResource(ctx context.Context, entityType string, entityName string, resourceToUpdate interface{}) error {
-	token := viper.GetString("token")
-	baseUrl := viper.GetString("url")
-
-	client := resty.New()
-	apiPrefixTemplate := "%v/ocean/cd/%v/%v"
-	apiUrl := fmt.Sprintf(apiPrefixTemplate, baseUrl, entityType, url.QueryEscape(entityName))
+func (c *Client) UpdateResource(ctx context.Context, entityType string, entityName string, resourceToUpdate interface{}) error {
+	apiPrefixTemplate := "/ocean/cd/%v/%v"
+	apiUrl := fmt.Sprintf(apiPrefixTemplate, entityType, url.QueryEscape(entityName))
 
-	response, err := client.R().
-		SetAuthToken(token).
-		ForceContentType("application/json").
+	response, err := c.newRequest(ctx).
 		SetBody(resourceToUpdate).
 		//	SetResult(model.OperationResponse{}).
 		Put(apiUrl)
@@ -60,24 +46,18 @@ func UpdateResource(ctx context.Context, entityType string, entityName string, r
 	}
 
 	if status := response.StatusCode(); status != 200 {
-		err = parseErrorFromResponse(response.Body())
+		err = parseErrorFromResponse(response)
 		return err
 	}
 
 	return nil
 }
 
-func DeleteEntity(ctx context.Context, entityType string, entityName string) error {
-	token := viper.GetString("token")
-	baseUrl := viper.GetString("url")
+func (c *Client) DeleteEntity(ctx context.Context, entityType string, entityName string) error {
+	apiPrefixTemplate := "/ocean/cd/%v/%v"
+	apiUrl := fmt.Sprintf(apiPrefixTemplate, entityType, url.QueryEscape(entityName))
 
-	client := resty.New()
-	apiPrefixTemplate := "%v/ocean/cd/%v/%v"
-	apiUrl := fmt.Sprintf(apiPrefixTemplate, baseUrl, entityType, url.QueryEscape(entityName))
-
-	response, err := client.R().
-		SetAuthToken(token).
-		ForceContentType("application/json").
+	response, err := c.newRequest(ctx).
 		Delete(apiUrl)
 
 	if err != nil {
@@ -85,24 +65,18 @@ func DeleteEntity(ctx context.Context, entityType string, entityName string) err
 	}
 
 	if status := response.StatusCode(); status != 200 {
-		err = parseErrorFromResponse(response.Body())
+		err = parseErrorFromResponse(response)
 		return err
 	}
 
 	return nil
 }
 
-func GetEntity(ctx context.Context, entityType string, entityName string) (interface{}, error) {
-	token := viper.GetString("token")
-	baseUrl := viper.GetString("url")
-
-	client := resty.New()
-	apiPrefixTemplate := "%v/ocean/cd/%v/%v"
-	apiUrl := fmt.Sprintf(apiPrefixTemplate, baseUrl, entityType, url.QueryEscape(entityName))
+func (c *Client) GetEntity(ctx context.Context, entityType string, entityName string) (interface{}, error) {
+	apiPrefixTemplate := "/ocean/cd/%v/%v"
+	apiUrl := fmt.Sprintf(apiPrefixTemplate, entityType, url.QueryEscape(entityName))
 
-	response, err := client.R().
-		SetAuthToken(token).
-		ForceContentType("application/json").
+	response, err := c.newRequest(ctx).
 		Get(apiUrl)
 
 	if err != nil {
@@ -110,7 +84,7 @@ func GetEntity(ctx context.Context, entityType string, entityName string) (inter
 	}
 
 	if status := response.StatusCode(); status != 200 {
-		err = parseErrorFromResponse(response.Body())
+		err = parseErrorFromResponse(response)
 		return nil, err
 	}
 
@@ -119,49 +93,23 @@ func GetEntity(ctx context.Context, entityType string, entityName string) (inter
 		return nil, err
 	}
 	if len(items) == 0 {
-		return nil, fmt.Errorf("error: Resource '%s/%s' does not exist", entityType, entityName)
+		return nil, newNotFoundError(response, "Resource '%s/%s' does not exist", entityType, entityName)
 	}
 
 	return items[0], nil
 }
 
-func parseErrorFromResponse(body []byte) error {
-	var spotResponse map[string]interface{}
-	err := json.Unmarshal(body, &spotResponse)
-	if err != nil {
-		return err
-	}
+func (c *Client) ListEntities(ctx context.Context, entityType string) ([]interface{}, error) {
+	apiPrefixTemplate := "/ocean/cd/%v"
+	apiUrl := fmt.Sprintf(apiPrefixTemplate, entityType)
 
-	innerResponse, isResponseKeyExist := spotResponse["response"]
-	if isResponseKeyExist == false {
-		return errors.New("error: Unknown server error")
-	}
-
-	innerErrors, isErrorsKeyExist := innerResponse.(map[string]interface{})["errors"]
-	if isErrorsKeyExist == false || len(innerErrors.([]interface{})) == 0 {
-		return errors.New("error: Unknown server error")
-	}
-
-	errorMessage, isMessageExists := innerErrors.([]interface{})[0].(map[string]interface{})["message"]
-	if isMessageExists == false {
-		return errors.New("error: Unknown server error")
+	response, err := c.newRequest(ctx).Get(apiUrl)
+	if err != nil {
+		return nil, err
 	}
 
-	return fmt.Errorf("error: %s", errorMessage.(string))
-}
-
-func ListEntities(ctx context.Context, entityType string) ([]interface{}, error) {
-	token := viper.GetString("token")
-	baseUrl := viper.GetString("url")
-
-	client := resty.New()
-	apiPrefixTemplate := "%v/ocean/cd/%v"
-	apiUrl := fmt.Sprintf(apiPrefixTemplate, baseUrl, entityType)
-
-	response, err := client.R().
-		SetAuthToken(token).
-		ForceContentType("application/json").Get(apiUrl)
-	if err != nil {
+	if status := response.StatusCode(); status != 200 {
+		err = parseErrorFromResponse(response)
 		return nil, err
 	}
 
@@ -174,7 +122,6 @@ func ListEntities(ctx context.Context, entityType string) ([]interface{}, error)
 }
 
 func unmarshalEntityResponse(response []byte) ([]interface{}, error) {
-
 	type MarshalHelper struct {
 		Request  map[string]interface{} `json:"request"`
 		Response struct {
@@ -200,17 +147,11 @@ func unmarshalEntityResponse(response []byte) ([]interface{}, error) {
 
 }
 
-func SendRolloutAction(rolloutId string, body map[string]string) error {
-	token := viper.GetString("token")
-	baseUrl := viper.GetString("url")
-
-	client := resty.New()
-	apiPrefixTemplate := "%v/ocean/cd/rollout/%s"
-	apiUrl := fmt.Sprintf(apiPrefixTemplate, baseUrl, rolloutId)
+func (c *Client) SendRolloutAction(ctx context.Context, rolloutId string, body map[string]string) error {
+	apiPrefixTemplate := "/ocean/cd/rollout/%s"
+	apiUrl := fmt.Sprintf(apiPrefixTemplate, rolloutId)
 
-	response, err := client.R().
-		SetAuthToken(token).
-		ForceContentType("application/json").
+	response, err := c.newRequest(ctx).
 		SetBody(body).
 		Put(apiUrl)
 
@@ -219,25 +160,20 @@ func SendRolloutAction(rolloutId string, body map[string]string) error {
 	}
 
 	if status := response.StatusCode(); status != 200 {
-		err = parseErrorFromResponse(response.Body())
+		err = parseErrorFromResponse(response)
 		return err
 	}
 
 	return nil
 }
 
-func GetRollout(rolloutId string) (rollout.Rollout, error) {
-	token := viper.GetString("token")
-	baseUrl := viper.GetString("url")
+func (c *Client) GetRollout(ctx context.Context, rolloutId string) (rollout.Rollout, error) {
 	rolloutInfo := rollout.Rollout{}
 
-	client := resty.New()
-	apiPrefixTemplate := "%v/ocean/cd/rollout/%s/status"
-	apiUrl := fmt.Sprintf(apiPrefixTemplate, baseUrl, rolloutId)
+	apiPrefixTemplate := "/ocean/cd/rollout/%s/status"
+	apiUrl := fmt.Sprintf(apiPrefixTemplate, rolloutId)
 
-	response, err := client.R().
-		SetAuthToken(token).
-		ForceContentType("application/json").
+	response, err := c.newRequest(ctx).
 		Get(apiUrl)
 
 	if err != nil {
@@ -245,11 +181,11 @@ func GetRollout(rolloutId string) (rollout.Rollout, error) {
 	}
 
 	if response.StatusCode() != 200 {
-		if response.StatusCode() == 400 {
-			return rolloutInfo, fmt.Errorf("error: Rollout %s does not exist", rolloutId)
+		if response.StatusCode() == http.StatusBadRequest || response.StatusCode() == http.StatusNotFound {
+			return rolloutInfo, newNotFoundError(response, "Rollout %s does not exist", rolloutId)
 		}
 
-		err = parseErrorFromResponse(response.Body())
+		err = parseErrorFromResponse(response)
 		return rolloutInfo, err
 	}
 
@@ -259,7 +195,7 @@ func GetRollout(rolloutId string) (rollout.Rollout, error) {
 	}
 
 	if len(items) == 0 {
-		return rolloutInfo, fmt.Errorf("error: Rollout %s does not exist", rolloutId)
+		return rolloutInfo, newNotFoundError(response, "Rollout %s does not exist", rolloutId)
 	}
 
 	if len(items) > 1 {
@@ -279,18 +215,56 @@ func GetRollout(rolloutId string) (rollout.Rollout, error) {
 	return rolloutInfo, nil
 }
 
-func GetRolloutPhases(rolloutId string) ([]phase.Phase, error) {
-	token := viper.GetString("token")
-	baseUrl := viper.GetString("url")
+func (c *Client) ListRollouts(ctx context.Context, filter RolloutFilter) ([]rollout.Rollout, error) {
+	rollouts := make([]rollout.Rollout, 0)
+
+	apiUrl := "/ocean/cd/rollout"
+
+	response, err := c.newRequest(ctx).
+		SetQueryParams(filter.queryParams()).
+		Get(apiUrl)
+
+	if err != nil {
+		return rollouts, err
+	}
+
+	if status := response.StatusCode(); status != 200 {
+		err = parseErrorFromResponse(response)
+		return rollouts, err
+	}
+
+	items, err := unmarshalEntityResponse(response.Body())
+	if err != nil {
+		return rollouts, err
+	}
+
+	for _, item := range items {
+		bytes, err := json.Marshal(item)
+		if err != nil {
+			return rollouts, fmt.Errorf("error: Failed to parse a rollout - %w", err)
+		}
+
+		rolloutInfo := rollout.Rollout{}
+		err = json.Unmarshal(bytes, &rolloutInfo)
+		if err != nil {
+			return rollouts, fmt.Errorf("error: Failed to parse a rollout - %w", err)
+		}
+
+		if filter.matchesStatus(rolloutInfo.Status) {
+			rollouts = append(rollouts, rolloutInfo)
+		}
+	}
+
+	return rollouts, nil
+}
+
+func (c *Client) GetRolloutPhases(ctx context.Context, rolloutId string) ([]phase.Phase, error) {
 	rolloutPhases := make([]phase.Phase, 0)
 
-	client := resty.New()
-	apiPrefixTemplate := "%v/ocean/cd/rollout/%s/phase"
-	apiUrl := fmt.Sprintf(apiPrefixTemplate, baseUrl, rolloutId)
+	apiPrefixTemplate := "/ocean/cd/rollout/%s/phase"
+	apiUrl := fmt.Sprintf(apiPrefixTemplate, rolloutId)
 
-	response, err := client.R().
-		SetAuthToken(token).
-		ForceContentType("application/json").
+	response, err := c.newRequest(ctx).
 		Get(apiUrl)
 
 	if err != nil {
@@ -298,11 +272,11 @@ func GetRolloutPhases(rolloutId string) ([]phase.Phase, error) {
 	}
 
 	if status := response.StatusCode(); status != 200 {
-		if response.StatusCode() == 400 {
-			return rolloutPhases, fmt.Errorf("error: Rollout phases for rollout %s do not exist", rolloutId)
+		if response.StatusCode() == http.StatusBadRequest || response.StatusCode() == http.StatusNotFound {
+			return rolloutPhases, newNotFoundError(response, "Rollout phases for rollout %s do not exist", rolloutId)
 		}
 
-		err = parseErrorFromResponse(response.Body())
+		err = parseErrorFromResponse(response)
 		return rolloutPhases, err
 	}
 
@@ -333,18 +307,13 @@ func GetRolloutPhases(rolloutId string) ([]phase.Phase, error) {
 	return rolloutPhases, nil
 }
 
-func GetRolloutVerifications(rolloutId string) ([]verification.Verification, error) {
-	token := viper.GetString("token")
-	baseUrl := viper.GetString("url")
+func (c *Client) GetRolloutVerifications(ctx context.Context, rolloutId string) ([]verification.Verification, error) {
 	rolloutVerifications := make([]verification.Verification, 0)
 
-	client := resty.New()
-	apiPrefixTemplate := "%v/ocean/cd/rollout/%s/verification"
-	apiUrl := fmt.Sprintf(apiPrefixTemplate, baseUrl, rolloutId)
+	apiPrefixTemplate := "/ocean/cd/rollout/%s/verification"
+	apiUrl := fmt.Sprintf(apiPrefixTemplate, rolloutId)
 
-	response, err := client.R().
-		SetAuthToken(token).
-		ForceContentType("application/json").
+	response, err := c.newRequest(ctx).
 		Get(apiUrl)
 
 	if err != nil {
@@ -352,11 +321,11 @@ func GetRolloutVerifications(rolloutId string) ([]verification.Verification, err
 	}
 
 	if status := response.StatusCode(); status != 200 {
-		if response.StatusCode() == 400 {
-			return rolloutVerifications, errors.New(fmt.Sprintf("error: Rollout verifications for rollout %s do not exist", rolloutId))
+		if response.StatusCode() == http.StatusBadRequest || response.StatusCode() == http.StatusNotFound {
+			return rolloutVerifications, newNotFoundError(response, "Rollout verifications for rollout %s do not exist", rolloutId)
 		}
 
-		err = parseErrorFromResponse(response.Body())
+		err = parseErrorFromResponse(response)
 		return rolloutVerifications, err
 	}
 
@@ -387,12 +356,8 @@ func GetRolloutVerifications(rolloutId string) ([]verification.Verification, err
 	return rolloutVerifications, nil
 }
 
-func SendWorkloadAction(pathParams PathParams, queryParams QueryParams) error {
-	token := viper.GetString("token")
-	client := resty.New()
-
-	response, err := client.R().
-		SetAuthToken(token).
+func (c *Client) SendWorkloadAction(ctx context.Context, pathParams PathParams, queryParams QueryParams) error {
+	response, err := c.newRequest(ctx).
 		SetQueryParams(queryParams).
 		SetPathParams(pathParams).
 		Put(buildWorkloadApiUrl(pathParams))
@@ -402,25 +367,20 @@ func SendWorkloadAction(pathParams PathParams, queryParams QueryParams) error {
 	}
 
 	if status := response.StatusCode(); status != 200 {
-		err = parseErrorFromResponse(response.Body())
+		err = parseErrorFromResponse(response)
 		return err
 	}
 
 	return nil
 }
 
-func GetRolloutDefinition(rolloutId string) (map[string]interface{}, error) {
-	token := viper.GetString("token")
-	baseUrl := viper.GetString("url")
+func (c *Client) GetRolloutDefinition(ctx context.Context, rolloutId string) (map[string]interface{}, error) {
 	var rolloutDefinition map[string]interface{}
 
-	client := resty.New()
-	apiPrefixTemplate := "%v/ocean/cd/rollout/%s/definition"
-	apiUrl := fmt.Sprintf(apiPrefixTemplate, baseUrl, rolloutId)
+	apiPrefixTemplate := "/ocean/cd/rollout/%s/definition"
+	apiUrl := fmt.Sprintf(apiPrefixTemplate, rolloutId)
 
-	response, err := client.R().
-		SetAuthToken(token).
-		ForceContentType("application/json").
+	response, err := c.newRequest(ctx).
 		Get(apiUrl)
 
 	if err != nil {
@@ -428,11 +388,11 @@ func GetRolloutDefinition(rolloutId string) (map[string]interface{}, error) {
 	}
 
 	if response.StatusCode() != 200 {
-		if response.StatusCode() == 400 {
-			return rolloutDefinition, errors.New(fmt.Sprintf("error: Rollout %s definition does not exist", rolloutId))
+		if response.StatusCode() == http.StatusBadRequest || response.StatusCode() == http.StatusNotFound {
+			return rolloutDefinition, newNotFoundError(response, "Rollout %s definition does not exist", rolloutId)
 		}
 
-		err = parseErrorFromResponse(response.Body())
+		err = parseErrorFromResponse(response)
 		return rolloutDefinition, err
 	}
 
@@ -442,7 +402,7 @@ func GetRolloutDefinition(rolloutId string) (map[string]interface{}, error) {
 	}
 
 	if len(items) == 0 {
-		return rolloutDefinition, fmt.Errorf("error: Rollout %s definition does not exist", rolloutId)
+		return rolloutDefinition, newNotFoundError(response, "Rollout %s definition does not exist", rolloutId)
 	}
 
 	if len(items) > 1 {
@@ -455,21 +415,22 @@ func GetRolloutDefinition(rolloutId string) (map[string]interface{}, error) {
 	}
 
 	err = json.Unmarshal(bytes, &rolloutDefinition)
+	if err != nil {
+		return rolloutDefinition, fmt.Errorf("error: Failed to parse the rollout definition - %w", err)
+	}
+
+	if rolloutDefinition == nil {
+		return rolloutDefinition, fmt.Errorf("error: Rollout %s has an empty definition", rolloutId)
+	}
 
 	return rolloutDefinition, nil
 }
 
-func GetOMInstallationManifests(_ context.Context, payload operator.OMManifestsRequest) (*operator.OMManifestsResponse, error) {
-	token := viper.GetString("token")
-	baseUrl := viper.GetString("url")
-
-	client := resty.New()
-	apiPrefixTemplate := "%v/ocean/cd/%v"
-	apiUrl := fmt.Sprintf(apiPrefixTemplate, baseUrl, "omInstaller")
+func (c *Client) GetOMInstallationManifests(ctx context.Context, payload operator.OMManifestsRequest) (*operator.OMManifestsResponse, error) {
+	apiPrefixTemplate := "/ocean/cd/%v"
+	apiUrl := fmt.Sprintf(apiPrefixTemplate, "omInstaller")
 
-	response, err := client.R().
-		SetAuthToken(token).
-		ForceContentType("application/json").
+	response, err := c.newRequest(ctx).
 		SetBody(payload).
 		Post(apiUrl)
 
@@ -478,7 +439,7 @@ func GetOMInstallationManifests(_ context.Context, payload operator.OMManifestsR
 	}
 
 	if status := response.StatusCode(); status != 200 {
-		err = parseErrorFromResponse(response.Body())
+		err = parseErrorFromResponse(response)
 		return nil, err
 	}
 
@@ -506,17 +467,11 @@ func GetOMInstallationManifests(_ context.Context, payload operator.OMManifestsR
 	return output, nil
 }
 
-func DeleteCluster(_ context.Context, clusterId string) (*operator.DeleteClusterResponse, error) {
-	token := viper.GetString("token")
-	baseUrl := viper.GetString("url")
+func (c *Client) DeleteCluster(ctx context.Context, clusterId string) (*operator.DeleteClusterResponse, error) {
+	apiPrefixTemplate := "/ocean/cd/cluster/%s"
+	apiUrl := fmt.Sprintf(apiPrefixTemplate, clusterId)
 
-	client := resty.New()
-	apiPrefixTemplate := "%v/ocean/cd/cluster/%s"
-	apiUrl := fmt.Sprintf(apiPrefixTemplate, baseUrl, clusterId)
-
-	response, err := client.R().
-		SetAuthToken(token).
-		ForceContentType("application/json").
+	response, err := c.newRequest(ctx).
 		Delete(apiUrl)
 
 	if err != nil {
@@ -524,7 +479,7 @@ func DeleteCluster(_ context.Context, clusterId string) (*operator.DeleteCluster
 	}
 
 	if status := response.StatusCode(); status != 200 {
-		err = parseErrorFromResponse(response.Body())
+		err = parseErrorFromResponse(response)
 		return nil, err
 	}
 
@@ -552,17 +507,10 @@ func DeleteCluster(_ context.Context, clusterId string) (*operator.DeleteCluster
 	return retVal, nil
 }
 
-func GetClusterManifests(_ context.Context) (*operator.ClusterManifestsMetadataResponse, error) {
-	token := viper.GetString("token")
-	baseUrl := viper.GetString("url")
-
-	client := resty.New()
-	apiPrefixTemplate := "%v/ocean/cd/cluster/manifest/metadata"
-	apiUrl := fmt.Sprintf(apiPrefixTemplate, baseUrl)
+func (c *Client) GetClusterManifests(ctx context.Context) (*operator.ClusterManifestsMetadataResponse, error) {
+	apiUrl := "/ocean/cd/cluster/manifest/metadata"
 
-	response, err := client.R().
-		SetAuthToken(token).
-		ForceContentType("application/json").
+	response, err := c.newRequest(ctx).
 		Get(apiUrl)
 
 	if err != nil {
@@ -570,7 +518,7 @@ func GetClusterManifests(_ context.Context) (*operator.ClusterManifestsMetadataR
 	}
 
 	if status := response.StatusCode(); status != 200 {
-		err = parseErrorFromResponse(response.Body())
+		err = parseErrorFromResponse(response)
 		return nil, err
 	}
 
@@ -598,18 +546,10 @@ func GetClusterManifests(_ context.Context) (*operator.ClusterManifestsMetadataR
 	return retVal, nil
 }
 
-func CreateClusterToken(_ context.Context) (*operator.ClusterTokenResponse, error) {
-	token := viper.GetString("token")
-	baseUrl := viper.GetString("url")
-	clusterId := viper.GetString("clusterId")
-
-	client := resty.New()
-	apiPrefixTemplate := "%v/ocean/cd/cluster/token"
-	apiUrl := fmt.Sprintf(apiPrefixTemplate, baseUrl)
+func (c *Client) CreateClusterToken(ctx context.Context, clusterId string) (*operator.ClusterTokenResponse, error) {
+	apiUrl := "/ocean/cd/cluster/token"
 
-	response, err := client.R().
-		SetAuthToken(token).
-		ForceContentType("application/json").
+	response, err := c.newRequest(ctx).
 		SetQueryParams(map[string]string{"clusterId": clusterId}).
 		Post(apiUrl)
 
@@ -618,7 +558,7 @@ func CreateClusterToken(_ context.Context) (*operator.ClusterTokenResponse, erro
 	}
 
 	if status := response.StatusCode(); status != 200 {
-		err = parseErrorFromResponse(response.Body())
+		err = parseErrorFromResponse(response)
 		return nil, err
 	}
 
@@ -647,8 +587,7 @@ func CreateClusterToken(_ context.Context) (*operator.ClusterTokenResponse, erro
 }
 
 func buildWorkloadApiUrl(params PathParams) string {
-	urlTemplate := fmt.Sprintf("%s/ocean/cd/workload/{spotDeploymentName}/namespace/{namespace}",
-		viper.GetString("url"))
+	urlTemplate := "/ocean/cd/workload/{spotDeploymentName}/namespace/{namespace}"
 
 	if params["action"] != RestartAction {
 		urlTemplate += "/revision/{revisionId}"
@@ -0,0 +1,58 @@
+package oceancd
+
+import (
+	"context"
+	"github.com/google/go-cmp/cmp"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetRolloutDefinition(t *testing.T) {
+	cases := map[string]struct {
+		body      string
+		expected  map[string]interface{}
+		expectErr bool
+		notFound  bool
+	}{
+		"definition": {
+			body:     `{"response": {"items": [{"strategy": {"canary": {"steps": [{"name": "first"}]}}}]}}`,
+			expected: map[string]interface{}{"strategy": map[string]interface{}{"canary": map[string]interface{}{"steps": []interface{}{map[string]interface{}{"name": "first"}}}}},
+		},
+		"malformed definition": {
+			body:      `{"response": {"items": ["strategy"]}}`,
+			expectErr: true,
+		},
+		"null definition": {
+			body:      `{"response": {"items": [null]}}`,
+			expectErr: true,
+		},
+		"no definition": {
+			body:      `{"response": {"items": []}}`,
+			expectErr: true,
+			notFound:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			actual, err := NewClient(ClientOptions{BaseUrl: server.URL}).GetRolloutDefinition(context.Background(), "rol-1")
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected an error %v, got %v", tc.expectErr, err)
+			}
+
+			if IsNotFound(err) != tc.notFound {
+				t.Errorf("expected IsNotFound %v, got %v", tc.notFound, err)
+			}
+
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("definition mismatch (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
@@ -1,100 +1,157 @@
 package builders
 
 import (
-	"spot-oceancd-cli/pkg/oceancd"
+	"context"
+	"errors"
+	"fmt"
+	"golang.org/x/sync/errgroup"
+	"sort"
 	"spot-oceancd-cli/pkg/oceancd/model/rollout"
 	"spot-oceancd-cli/pkg/oceancd/repositories"
+	"strings"
 	"sync"
 )
 
-type DetailedRolloutBuilder struct {
-	detailedRollout   *rollout.DetailedRollout
-	rolloutRepository *repositories.RolloutRepository
-	errors            chan error
-	wg                *sync.WaitGroup
-	withStrategy      bool
+// The parts a detailed rollout is built of, fetched concurrently
+const (
+	StatusFetch        = "status"
+	PhasesFetch        = "phases"
+	VerificationsFetch = "verifications"
+	DefinitionFetch    = "definition"
+)
+
+// FetchError is the failure of a single part of a detailed rollout
+type FetchError struct {
+	Fetch string
+	Err   error
 }
 
-func NewDetailedRolloutBuilder(rolloutRepository *repositories.RolloutRepository) *DetailedRolloutBuilder {
-	return &DetailedRolloutBuilder{
-		rolloutRepository: rolloutRepository,
-	}
+func (e *FetchError) Error() string {
+	return fmt.Sprintf("error: Failed to get the rollout %s - %s", e.Fetch, strings.TrimPrefix(e.Err.Error(), "error: "))
 }
 
-func (b *DetailedRolloutBuilder) Build(rolloutId string) (*rollout.DetailedRollout, error) {
-	b.detailedRollout = &rollout.DetailedRollout{}
-	b.errors = make(chan error)
+func (e *FetchError) Unwrap() error {
+	return e.Err
+}
 
-	b.wg = &sync.WaitGroup{}
+// BuildError joins the failures of every part of a detailed rollout that could not be fetched
+type BuildError struct {
+	RolloutId string
+	Errors    []*FetchError
+}
 
-	if b.withStrategy {
-		//here b.wg begins waiting for the next goroutine: setStrategy()
-		b.wg.Add(1)
-		go b.setStrategy(rolloutId)
+func (e *BuildError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
 	}
 
-	//here b.wg begins waiting for the next goroutines: setRollout(), setRolloutPhases(), setRolloutVerifications()
-	b.wg.Add(3)
+	return strings.Join(messages, "\n")
+}
 
-	go b.setRollout(rolloutId)
-	go b.setRolloutPhases(rolloutId)
-	go b.setRolloutVerifications(rolloutId)
+// Unwrap returns the first failure, so the api errors of a build are still recognized with errors.As
+func (e *BuildError) Unwrap() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
 
-	b.wg.Wait()
+	return e.Errors[0]
+}
 
-	select {
-	case err := <-b.errors:
-		return b.detailedRollout, err
-	default:
-		return b.detailedRollout, nil
+// Failed reports whether the given part of the rollout could not be fetched
+func (e *BuildError) Failed(fetch string) bool {
+	for _, err := range e.Errors {
+		if err.Fetch == fetch {
+			return true
+		}
 	}
-}
 
-func (b *DetailedRolloutBuilder) setStrategy(rolloutId string) {
-	defer b.wg.Done()
+	return false
+}
 
-	strategy, err := b.rolloutRepository.GetStrategy(rolloutId)
-	if err != nil {
-		b.errors <- err
-	}
+type DetailedRolloutBuilder struct {
+	repository   repositories.RolloutRepository
+	withStrategy bool
+}
 
-	b.detailedRollout.Definition.Strategy = strategy
+func NewDetailedRolloutBuilder(repository repositories.RolloutRepository) *DetailedRolloutBuilder {
+	return &DetailedRolloutBuilder{repository: repository}
 }
 
-func (b *DetailedRolloutBuilder) setRollout(rolloutId string) {
-	defer b.wg.Done()
+func (b *DetailedRolloutBuilder) WithStrategy() *DetailedRolloutBuilder {
+	b.withStrategy = true
+	return b
+}
 
-	fetchedRollout, err := oceancd.GetRollout(rolloutId)
-	if err != nil {
-		b.errors <- err
+// Build fetches every part of the rollout concurrently. The first failure cancels the remaining fetches,
+// so the build error lists the parts that failed before then, and no partial rollout is returned on failure.
+func (b *DetailedRolloutBuilder) Build(ctx context.Context, rolloutId string) (*rollout.DetailedRollout, error) {
+	detailedRollout := &rollout.DetailedRollout{}
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	mutex := sync.Mutex{}
+	var fetchErrors []*FetchError
+
+	fetch := func(name string, fn func(ctx context.Context) error) {
+		group.Go(func() error {
+			err := fn(groupCtx)
+			if err == nil {
+				return nil
+			}
+
+			// the fetches cancelled by the failure of another one are not failures of their own
+			if errors.Is(err, context.Canceled) && ctx.Err() == nil {
+				return err
+			}
+
+			mutex.Lock()
+			fetchErrors = append(fetchErrors, &FetchError{Fetch: name, Err: err})
+			mutex.Unlock()
+
+			return err
+		})
 	}
 
-	b.detailedRollout.Rollout = fetchedRollout
-}
+	fetch(StatusFetch, func(ctx context.Context) (err error) {
+		detailedRollout.Rollout, err = b.repository.GetRollout(ctx, rolloutId)
+		return err
+	})
 
-func (b *DetailedRolloutBuilder) setRolloutPhases(rolloutId string) {
-	defer b.wg.Done()
+	fetch(PhasesFetch, func(ctx context.Context) (err error) {
+		detailedRollout.Phases, err = b.repository.GetRolloutPhases(ctx, rolloutId)
+		return err
+	})
 
-	phases, err := oceancd.GetRolloutPhases(rolloutId)
-	if err != nil {
-		b.errors <- err
-	}
+	fetch(VerificationsFetch, func(ctx context.Context) (err error) {
+		detailedRollout.Verifications, err = b.repository.GetRolloutVerifications(ctx, rolloutId)
+		return err
+	})
 
-	b.detailedRollout.Phases = phases
-}
+	if b.withStrategy {
+		fetch(DefinitionFetch, func(ctx context.Context) (err error) {
+			detailedRollout.Definition.Strategy, err = b.repository.GetStrategy(ctx, rolloutId)
+			return err
+		})
+	}
 
-func (b *DetailedRolloutBuilder) setRolloutVerifications(rolloutId string) {
-	defer b.wg.Done()
+	if group.Wait() == nil {
+		return detailedRollout, nil
+	}
 
-	verifications, err := oceancd.GetRolloutVerifications(rolloutId)
-	if err != nil {
-		b.errors <- err
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
 	}
 
-	b.detailedRollout.Verifications = verifications
+	sortFetchErrors(fetchErrors)
+
+	return nil, &BuildError{RolloutId: rolloutId, Errors: fetchErrors}
 }
 
-func (b *DetailedRolloutBuilder) WithStrategy() *DetailedRolloutBuilder {
-	b.withStrategy = true
-	return b
+// sortFetchErrors orders the failures the same way the parts are fetched, so the joined error is stable
+func sortFetchErrors(fetchErrors []*FetchError) {
+	order := map[string]int{StatusFetch: 0, PhasesFetch: 1, VerificationsFetch: 2, DefinitionFetch: 3}
+
+	sort.Slice(fetchErrors, func(i, j int) bool {
+		return order[fetchErrors[i].Fetch] < order[fetchErrors[j].Fetch]
+	})
 }
@@ -0,0 +1,278 @@
+package builders
+
+import (
+	"context"
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"net/http"
+	"sort"
+	"spot-oceancd-cli/pkg/oceancd"
+	"spot-oceancd-cli/pkg/oceancd/model/phase"
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+	"spot-oceancd-cli/pkg/oceancd/model/strategy"
+	"spot-oceancd-cli/pkg/oceancd/model/verification"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testRolloutId = "rol-a78dsds9s"
+
+var (
+	errUnavailable = &oceancd.APIError{StatusCode: http.StatusServiceUnavailable, Messages: []string{"Service unavailable"}}
+	errNotFound    = &oceancd.APIError{StatusCode: http.StatusNotFound, Messages: []string{"Rollout rol-a78dsds9s does not exist"}}
+)
+
+// fakeRolloutRepository answers with the configured parts, or fails the fetches that have an error.
+// A blocked fetch only returns once its context is done, as an http request would when cancelled.
+// Every fetch that returned because of its context is recorded in cancelled.
+type fakeRolloutRepository struct {
+	errors    map[string]error
+	blocked   map[string]bool
+	mutex     sync.Mutex
+	cancelled []string
+}
+
+func (f *fakeRolloutRepository) answer(ctx context.Context, fetch string) error {
+	if f.blocked[fetch] {
+		<-ctx.Done()
+
+		f.mutex.Lock()
+		f.cancelled = append(f.cancelled, fetch)
+		f.mutex.Unlock()
+
+		return ctx.Err()
+	}
+
+	return f.errors[fetch]
+}
+
+func (f *fakeRolloutRepository) GetRollout(ctx context.Context, rolloutId string) (rollout.Rollout, error) {
+	if err := f.answer(ctx, StatusFetch); err != nil {
+		return rollout.Rollout{}, err
+	}
+
+	return rollout.Rollout{Id: rolloutId, Status: rollout.InProgress, SpotDeployment: "checkout"}, nil
+}
+
+func (f *fakeRolloutRepository) GetRolloutPhases(ctx context.Context, _ string) ([]phase.Phase, error) {
+	if err := f.answer(ctx, PhasesFetch); err != nil {
+		return nil, err
+	}
+
+	return []phase.Phase{{Name: "first"}}, nil
+}
+
+func (f *fakeRolloutRepository) GetRolloutVerifications(ctx context.Context, _ string) ([]verification.Verification, error) {
+	if err := f.answer(ctx, VerificationsFetch); err != nil {
+		return nil, err
+	}
+
+	return []verification.Verification{{MetricName: "error-rate"}}, nil
+}
+
+func (f *fakeRolloutRepository) GetStrategy(ctx context.Context, _ string) (rollout.Strategy, error) {
+	if err := f.answer(ctx, DefinitionFetch); err != nil {
+		return nil, err
+	}
+
+	return &strategy.CanaryStrategy{Steps: []strategy.Step{{Name: "first"}}}, nil
+}
+
+func (f *fakeRolloutRepository) GetRolloutDefinition(context.Context, string) (map[string]interface{}, error) {
+	return nil, errors.New("error: The builder fetches the strategy only")
+}
+
+func (f *fakeRolloutRepository) ListRollouts(context.Context, oceancd.RolloutFilter) ([]rollout.Rollout, error) {
+	return nil, errors.New("error: The builder does not list rollouts")
+}
+
+func (f *fakeRolloutRepository) SendRolloutAction(context.Context, string, map[string]string) error {
+	return errors.New("error: The builder does not send actions")
+}
+
+func TestDetailedRolloutBuilderBuild(t *testing.T) {
+	cases := map[string]struct {
+		source        *fakeRolloutRepository
+		withStrategy  bool
+		expected      *rollout.DetailedRollout
+		expectedFails []string
+	}{
+		"without strategy": {
+			source: &fakeRolloutRepository{},
+			expected: &rollout.DetailedRollout{
+				Rollout:       rollout.Rollout{Id: testRolloutId, Status: rollout.InProgress, SpotDeployment: "checkout"},
+				Phases:        []phase.Phase{{Name: "first"}},
+				Verifications: []verification.Verification{{MetricName: "error-rate"}},
+			},
+		},
+		"with strategy": {
+			source:       &fakeRolloutRepository{},
+			withStrategy: true,
+			expected: &rollout.DetailedRollout{
+				Rollout:       rollout.Rollout{Id: testRolloutId, Status: rollout.InProgress, SpotDeployment: "checkout"},
+				Definition:    rollout.Definition{Strategy: &strategy.CanaryStrategy{Steps: []strategy.Step{{Name: "first"}}}},
+				Phases:        []phase.Phase{{Name: "first"}},
+				Verifications: []verification.Verification{{MetricName: "error-rate"}},
+			},
+		},
+		"failed status": {
+			source:        &fakeRolloutRepository{errors: map[string]error{StatusFetch: errNotFound}},
+			expectedFails: []string{StatusFetch},
+		},
+		"failed definition": {
+			source:        &fakeRolloutRepository{errors: map[string]error{DefinitionFetch: errUnavailable}},
+			withStrategy:  true,
+			expectedFails: []string{DefinitionFetch},
+		},
+		"definition is not fetched without strategy": {
+			source: &fakeRolloutRepository{errors: map[string]error{DefinitionFetch: errUnavailable}},
+			expected: &rollout.DetailedRollout{
+				Rollout:       rollout.Rollout{Id: testRolloutId, Status: rollout.InProgress, SpotDeployment: "checkout"},
+				Phases:        []phase.Phase{{Name: "first"}},
+				Verifications: []verification.Verification{{MetricName: "error-rate"}},
+			},
+		},
+		"every fetch failed": {
+			source: &fakeRolloutRepository{errors: map[string]error{
+				StatusFetch:        errUnavailable,
+				PhasesFetch:        errUnavailable,
+				VerificationsFetch: errUnavailable,
+				DefinitionFetch:    errUnavailable,
+			}},
+			withStrategy:  true,
+			expectedFails: []string{StatusFetch, PhasesFetch, VerificationsFetch, DefinitionFetch},
+		},
+		"failure cancels the pending fetches": {
+			source: &fakeRolloutRepository{
+				errors:  map[string]error{PhasesFetch: errUnavailable},
+				blocked: map[string]bool{StatusFetch: true, VerificationsFetch: true, DefinitionFetch: true},
+			},
+			withStrategy:  true,
+			expectedFails: []string{PhasesFetch},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			builder := NewDetailedRolloutBuilder(tc.source)
+			if tc.withStrategy {
+				builder.WithStrategy()
+			}
+
+			actual, err := build(t, context.Background(), builder)
+
+			if len(tc.expectedFails) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(tc.expected, actual); diff != "" {
+					t.Errorf("mismatch (-expected +actual):\n%s", diff)
+				}
+				return
+			}
+
+			if actual != nil {
+				t.Errorf("expected no partial rollout, got %+v", actual)
+			}
+
+			var buildErr *BuildError
+			if errors.As(err, &buildErr) == false {
+				t.Fatalf("expected a build error, got %v", err)
+			}
+
+			fails := make([]string, len(buildErr.Errors))
+			for i, fetchErr := range buildErr.Errors {
+				fails[i] = fetchErr.Fetch
+			}
+
+			if diff := cmp.Diff(tc.expectedFails, fails); diff != "" {
+				t.Errorf("failed fetches mismatch (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDetailedRolloutBuilderBuildErrors(t *testing.T) {
+	builder := NewDetailedRolloutBuilder(&fakeRolloutRepository{errors: map[string]error{
+		StatusFetch:     errNotFound,
+		DefinitionFetch: errUnavailable,
+	}}).WithStrategy()
+
+	_, err := build(t, context.Background(), builder)
+
+	expected := "error: Failed to get the rollout status - Rollout rol-a78dsds9s does not exist\n" +
+		"error: Failed to get the rollout definition - Service unavailable"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error %q, got %v", expected, err)
+	}
+
+	if oceancd.IsNotFound(err) == false {
+		t.Errorf("expected the not found api error of the status to be unwrapped, got %v", err)
+	}
+}
+
+func TestDetailedRolloutBuilderBuildCancelsPendingFetches(t *testing.T) {
+	source := &fakeRolloutRepository{
+		errors:  map[string]error{StatusFetch: errNotFound},
+		blocked: map[string]bool{PhasesFetch: true, VerificationsFetch: true, DefinitionFetch: true},
+	}
+
+	_, err := build(t, context.Background(), NewDetailedRolloutBuilder(source).WithStrategy())
+	if oceancd.IsNotFound(err) == false {
+		t.Fatalf("expected the failure of the status, got %v", err)
+	}
+
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+
+	sort.Strings(source.cancelled)
+	expected := []string{DefinitionFetch, PhasesFetch, VerificationsFetch}
+	if diff := cmp.Diff(expected, source.cancelled); diff != "" {
+		t.Errorf("cancelled fetches mismatch (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestDetailedRolloutBuilderBuildCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	source := &fakeRolloutRepository{blocked: map[string]bool{StatusFetch: true}}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	actual, err := build(t, ctx, NewDetailedRolloutBuilder(source))
+	if errors.Is(err, context.Canceled) == false {
+		t.Errorf("expected the context error, got %v", err)
+	}
+
+	if actual != nil {
+		t.Errorf("expected no partial rollout, got %+v", actual)
+	}
+}
+
+// build fails the test instead of hanging when the builder deadlocks
+func build(t *testing.T, ctx context.Context, builder *DetailedRolloutBuilder) (*rollout.DetailedRollout, error) {
+	t.Helper()
+
+	type result struct {
+		detailedRollout *rollout.DetailedRollout
+		err             error
+	}
+
+	results := make(chan result, 1)
+	go func() {
+		detailedRollout, err := builder.Build(ctx, testRolloutId)
+		results <- result{detailedRollout, err}
+	}()
+
+	select {
+	case r := <-results:
+		return r.detailedRollout, r.err
+	case <-time.After(5 * time.Second):
+		t.Fatal("the builder did not return")
+		return nil, nil
+	}
+}
@@ -0,0 +1,94 @@
+package oceancd
+
+import (
+	"context"
+	"github.com/go-resty/resty/v2"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultBaseUrl    = "https://api.spotinst.io"
+	DefaultClusterUrl = "https://cluster-gateway.oceancd.io"
+	DefaultUserAgent  = "spot-oceancd-cli"
+)
+
+// ClientOptions holds everything needed to talk to the Ocean CD API.
+// Empty values fall back to the public Spot defaults.
+type ClientOptions struct {
+	BaseUrl    string
+	ClusterUrl string
+	Token      string
+	HttpClient *http.Client
+	UserAgent  string
+	// Timeout bounds every single request. Zero means no timeout.
+	Timeout time.Duration
+	// MaxRetries enables retrying of transient failures. Zero disables retries.
+	MaxRetries    int
+	RetryWaitTime time.Duration
+}
+
+// Client is a reusable Ocean CD API client. It is safe for concurrent use.
+type Client struct {
+	options    ClientOptions
+	httpClient *resty.Client
+	retries    *retryRecorder
+}
+
+func NewClient(options ClientOptions) *Client {
+	if options.BaseUrl == "" {
+		options.BaseUrl = DefaultBaseUrl
+	}
+
+	if options.ClusterUrl == "" {
+		options.ClusterUrl = DefaultClusterUrl
+	}
+
+	if options.UserAgent == "" {
+		options.UserAgent = DefaultUserAgent
+	}
+
+	options.BaseUrl = strings.TrimSuffix(options.BaseUrl, "/")
+
+	var httpClient *resty.Client
+	if options.HttpClient != nil {
+		httpClient = resty.NewWithClient(options.HttpClient)
+	} else {
+		httpClient = resty.New()
+		// watching rollouts polls the same resources over and over, unchanged ones are only revalidated
+		httpClient.SetTransport(newConditionalTransport(httpClient.GetClient().Transport))
+	}
+
+	httpClient.
+		SetBaseURL(options.BaseUrl).
+		SetAuthToken(options.Token).
+		SetHeader("User-Agent", options.UserAgent)
+
+	if options.Timeout > 0 {
+		httpClient.SetTimeout(options.Timeout)
+	}
+
+	retries := newRetryRecorder()
+	configureRetries(httpClient, options, retries)
+
+	return &Client{
+		options:    options,
+		httpClient: httpClient,
+		retries:    retries,
+	}
+}
+
+func (c *Client) BaseUrl() string {
+	return c.options.BaseUrl
+}
+
+func (c *Client) ClusterUrl() string {
+	return c.options.ClusterUrl
+}
+
+func (c *Client) newRequest(ctx context.Context) *resty.Request {
+	return c.httpClient.R().
+		SetContext(ctx).
+		ForceContentType("application/json")
+}
@@ -0,0 +1,103 @@
+package oceancd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+)
+
+const (
+	etagHeader        = "ETag"
+	ifNoneMatchHeader = "If-None-Match"
+)
+
+// conditionalTransport revalidates the GET responses that came with an ETag by sending If-None-Match.
+// A 304 Not Modified answer is replaced by the cached response, so callers always receive a full body
+// while an unchanged resource is not downloaded again. Responses without an ETag are not cached.
+type conditionalTransport struct {
+	next    http.RoundTripper
+	mutex   sync.Mutex
+	entries map[string]cachedResponse
+}
+
+type cachedResponse struct {
+	etag   string
+	header http.Header
+	body   []byte
+}
+
+func newConditionalTransport(next http.RoundTripper) *conditionalTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &conditionalTransport{next: next, entries: map[string]cachedResponse{}}
+}
+
+func (t *conditionalTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Method != http.MethodGet || request.Header.Get(ifNoneMatchHeader) != "" {
+		return t.next.RoundTrip(request)
+	}
+
+	key := request.URL.String()
+
+	t.mutex.Lock()
+	cached, isCached := t.entries[key]
+	t.mutex.Unlock()
+
+	if isCached {
+		// a round tripper must not modify the request it was given
+		request = request.Clone(request.Context())
+		request.Header.Set(ifNoneMatchHeader, cached.etag)
+	}
+
+	response, err := t.next.RoundTrip(request)
+	if err != nil {
+		return response, err
+	}
+
+	if isCached && response.StatusCode == http.StatusNotModified {
+		response.Body.Close()
+		return cached.toResponse(request), nil
+	}
+
+	etag := response.Header.Get(etagHeader)
+	if response.StatusCode != http.StatusOK || etag == "" {
+		if response.StatusCode == http.StatusOK && isCached {
+			t.mutex.Lock()
+			delete(t.entries, key)
+			t.mutex.Unlock()
+		}
+
+		return response, nil
+	}
+
+	body, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	t.mutex.Lock()
+	t.entries[key] = cachedResponse{etag: etag, header: response.Header.Clone(), body: body}
+	t.mutex.Unlock()
+
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	return response, nil
+}
+
+func (r cachedResponse) toResponse(request *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.body)),
+		ContentLength: int64(len(r.body)),
+		Request:       request,
+	}
+}
@@ -1,5 +1,12 @@
 package oceancd
 
+import (
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+	"strconv"
+	"strings"
+	"time"
+)
+
 var (
 	PromoteAction     = "promote"
 	PromoteFullAction = "promoteFull"
@@ -13,3 +20,73 @@ var (
 type QueryParams map[string]string
 
 type PathParams map[string]string
+
+// RolloutFilter narrows down the rollouts returned by ListRollouts, empty fields match everything
+type RolloutFilter struct {
+	ClusterId      string
+	Namespace      string
+	SpotDeployment string
+	Statuses       []rollout.Status
+	Since          time.Time
+}
+
+func (f RolloutFilter) queryParams() QueryParams {
+	queryParams := QueryParams{}
+
+	if f.ClusterId != "" {
+		queryParams["clusterId"] = f.ClusterId
+	}
+
+	if f.Namespace != "" {
+		queryParams["namespace"] = f.Namespace
+	}
+
+	if f.SpotDeployment != "" {
+		queryParams["spotDeployment"] = f.SpotDeployment
+	}
+
+	if f.Since.IsZero() == false {
+		queryParams["fromDate"] = strconv.FormatInt(f.Since.UnixMilli(), 10)
+	}
+
+	return queryParams
+}
+
+// matchesStatus is evaluated locally since the api does not filter rollouts by status
+func (f RolloutFilter) matchesStatus(status rollout.Status) bool {
+	if len(f.Statuses) == 0 {
+		return true
+	}
+
+	for _, filterStatus := range f.Statuses {
+		if strings.EqualFold(string(filterStatus), string(status)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Matches evaluates the whole filter locally, for rollouts that do not come from the api
+func (f RolloutFilter) Matches(rolloutItem rollout.Rollout) bool {
+	if f.ClusterId != "" && f.ClusterId != rolloutItem.ClusterId {
+		return false
+	}
+
+	if f.Namespace != "" && f.Namespace != rolloutItem.Namespace {
+		return false
+	}
+
+	if f.SpotDeployment != "" && f.SpotDeployment != rolloutItem.SpotDeployment {
+		return false
+	}
+
+	if f.Since.IsZero() == false {
+		startTime, err := time.Parse(time.RFC3339, rolloutItem.StartTime)
+		if err == nil && startTime.Before(f.Since) {
+			return false
+		}
+	}
+
+	return f.matchesStatus(rolloutItem.Status)
+}
@@ -0,0 +1,147 @@
+package oceancd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-resty/resty/v2"
+	"net/http"
+	"strings"
+)
+
+const (
+	unknownServerErrorMessage = "Unknown server error"
+	notFoundErrorCode         = "NOT_FOUND"
+)
+
+// APIError is returned for every unsuccessful Ocean CD api response
+type APIError struct {
+	StatusCode int
+	Code       string
+	Messages   []string
+	RequestId  string
+}
+
+func (e *APIError) Error() string {
+	message := strings.Join(e.Messages, "; ")
+	if message == "" {
+		message = unknownServerErrorMessage
+
+		if e.StatusCode != 0 {
+			message = fmt.Sprintf("%s - %d %s", message, e.StatusCode, http.StatusText(e.StatusCode))
+		}
+	}
+
+	if e.RequestId != "" {
+		return fmt.Sprintf("error: %s (request id: %s)", message, e.RequestId)
+	}
+
+	return fmt.Sprintf("error: %s", message)
+}
+
+func (e *APIError) hasCodeSuffix(suffixes ...string) bool {
+	code := strings.ToUpper(e.Code)
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(code, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) == false {
+		return false
+	}
+
+	return apiErr.StatusCode == http.StatusNotFound || apiErr.hasCodeSuffix(notFoundErrorCode, "NOT_EXIST", "DOES_NOT_EXIST")
+}
+
+func IsConflict(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) == false {
+		return false
+	}
+
+	return apiErr.StatusCode == http.StatusConflict || apiErr.hasCodeSuffix("ALREADY_EXISTS", "CONFLICT")
+}
+
+func IsUnauthorized(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) == false {
+		return false
+	}
+
+	return apiErr.StatusCode == http.StatusUnauthorized
+}
+
+func IsForbidden(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) == false {
+		return false
+	}
+
+	return apiErr.StatusCode == http.StatusForbidden
+}
+
+func newNotFoundError(response *resty.Response, format string, args ...interface{}) *APIError {
+	return &APIError{
+		StatusCode: http.StatusNotFound,
+		Code:       notFoundErrorCode,
+		Messages:   []string{fmt.Sprintf(format, args...)},
+		RequestId:  requestIdFromHeader(response),
+	}
+}
+
+func parseErrorFromResponse(response *resty.Response) error {
+	type spotError struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+
+	type spotErrorResponse struct {
+		Request struct {
+			Id string `json:"id"`
+		} `json:"request"`
+		Response struct {
+			Errors []spotError `json:"errors"`
+		} `json:"response"`
+	}
+
+	apiErr := &APIError{
+		StatusCode: response.StatusCode(),
+		Messages:   make([]string, 0),
+		RequestId:  requestIdFromHeader(response),
+	}
+
+	envelope := spotErrorResponse{}
+	if err := json.Unmarshal(response.Body(), &envelope); err != nil {
+		return apiErr
+	}
+
+	if envelope.Request.Id != "" {
+		apiErr.RequestId = envelope.Request.Id
+	}
+
+	for _, spotErr := range envelope.Response.Errors {
+		if apiErr.Code == "" {
+			apiErr.Code = spotErr.Code
+		}
+
+		if spotErr.Message != "" {
+			apiErr.Messages = append(apiErr.Messages, spotErr.Message)
+		}
+	}
+
+	return apiErr
+}
+
+func requestIdFromHeader(response *resty.Response) string {
+	if response == nil {
+		return ""
+	}
+
+	return response.Header().Get("X-Request-Id")
+}
@@ -0,0 +1,144 @@
+package oceancd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/go-cmp/cmp"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseErrorFromResponse(t *testing.T) {
+	cases := map[string]struct {
+		status          int
+		body            string
+		requestIdHeader string
+		expected        *APIError
+		expectedMessage string
+		notFound        bool
+		conflict        bool
+	}{
+		"multiple errors": {
+			status: http.StatusBadRequest,
+			body: `{"request": {"id": "req-1"}, "response": {"errors": [
+				{"code": "VALIDATION_ERROR", "message": "name is required"},
+				{"code": "OTHER_ERROR", "message": "steps are empty"}]}}`,
+			expected: &APIError{StatusCode: http.StatusBadRequest, Code: "VALIDATION_ERROR",
+				Messages: []string{"name is required", "steps are empty"}, RequestId: "req-1"},
+			expectedMessage: "error: name is required; steps are empty (request id: req-1)",
+		},
+		"request id of the body over the header": {
+			status:          http.StatusInternalServerError,
+			body:            `{"request": {"id": "req-body"}, "response": {"errors": []}}`,
+			requestIdHeader: "req-header",
+			expected:        &APIError{StatusCode: http.StatusInternalServerError, Messages: []string{}, RequestId: "req-body"},
+			expectedMessage: "error: Unknown server error - 500 Internal Server Error (request id: req-body)",
+		},
+		"not found code": {
+			status:          http.StatusBadRequest,
+			body:            `{"response": {"errors": [{"code": "STRATEGY_DOES_NOT_EXIST", "message": "strategy not found"}]}}`,
+			expected:        &APIError{StatusCode: http.StatusBadRequest, Code: "STRATEGY_DOES_NOT_EXIST", Messages: []string{"strategy not found"}},
+			expectedMessage: "error: strategy not found",
+			notFound:        true,
+		},
+		"not found status": {
+			status:          http.StatusNotFound,
+			body:            `{"response": {"errors": [{"code": "GENERAL", "message": "missing"}]}}`,
+			expected:        &APIError{StatusCode: http.StatusNotFound, Code: "GENERAL", Messages: []string{"missing"}},
+			expectedMessage: "error: missing",
+			notFound:        true,
+		},
+		"conflict code": {
+			status:          http.StatusBadRequest,
+			body:            `{"response": {"errors": [{"code": "ENTITY_ALREADY_EXISTS", "message": "strategy exists"}]}}`,
+			expected:        &APIError{StatusCode: http.StatusBadRequest, Code: "ENTITY_ALREADY_EXISTS", Messages: []string{"strategy exists"}},
+			expectedMessage: "error: strategy exists",
+			conflict:        true,
+		},
+		"conflict status": {
+			status:          http.StatusConflict,
+			body:            `{}`,
+			expected:        &APIError{StatusCode: http.StatusConflict, Messages: []string{}},
+			expectedMessage: "error: Unknown server error - 409 Conflict",
+			conflict:        true,
+		},
+		"non json body": {
+			status:          http.StatusBadGateway,
+			body:            "<html>Bad Gateway</html>",
+			requestIdHeader: "req-header",
+			expected:        &APIError{StatusCode: http.StatusBadGateway, Messages: []string{}, RequestId: "req-header"},
+			expectedMessage: "error: Unknown server error - 502 Bad Gateway (request id: req-header)",
+		},
+		"empty body": {
+			status:          http.StatusUnauthorized,
+			expected:        &APIError{StatusCode: http.StatusUnauthorized, Messages: []string{}},
+			expectedMessage: "error: Unknown server error - 401 Unauthorized",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tc.requestIdHeader != "" {
+					w.Header().Set("X-Request-Id", tc.requestIdHeader)
+				}
+
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			_, err := NewClient(ClientOptions{BaseUrl: server.URL}).ListEntities(context.Background(), "strategy")
+
+			var apiErr *APIError
+			if errors.As(err, &apiErr) == false {
+				t.Fatalf("expected an api error, got %v", err)
+			}
+
+			if diff := cmp.Diff(tc.expected, apiErr); diff != "" {
+				t.Errorf("api error mismatch (-expected +actual):\n%s", diff)
+			}
+
+			if apiErr.Error() != tc.expectedMessage {
+				t.Errorf("expected message %q, got %q", tc.expectedMessage, apiErr.Error())
+			}
+
+			// wrapped errors are still recognized
+			wrapped := fmt.Errorf("error: Resource 'strategy/canary' failed: %w", err)
+			if IsNotFound(wrapped) != tc.notFound {
+				t.Errorf("expected IsNotFound %v", tc.notFound)
+			}
+
+			if IsConflict(wrapped) != tc.conflict {
+				t.Errorf("expected IsConflict %v", tc.conflict)
+			}
+		})
+	}
+}
+
+func TestGetEntityNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		_, _ = w.Write([]byte(`{"response": {"items": []}}`))
+	}))
+	defer server.Close()
+
+	_, err := NewClient(ClientOptions{BaseUrl: server.URL}).GetEntity(context.Background(), "strategy", "canary")
+	if IsNotFound(err) == false {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+
+	expected := "error: Resource 'strategy/canary' does not exist (request id: req-1)"
+	if err.Error() != expected {
+		t.Errorf("expected message %q, got %q", expected, err.Error())
+	}
+}
+
+func TestErrorHelpersOfOtherErrors(t *testing.T) {
+	err := errors.New("error: connection refused")
+	if IsNotFound(err) || IsConflict(err) || IsUnauthorized(err) || IsForbidden(err) {
+		t.Errorf("expected a non api error not to match any helper")
+	}
+}
@@ -0,0 +1,236 @@
+package manifests
+
+import (
+	"fmt"
+	"sort"
+	"spot-oceancd-cli/pkg/oceancd/model"
+	"strings"
+)
+
+// Resource is a single document of the manifests, with the request body creating or updating it
+type Resource struct {
+	EntityType string
+	Name       string
+	Body       map[string]interface{}
+	Source     string
+}
+
+func (r *Resource) Key() string {
+	return fmt.Sprintf("%s/%s", r.EntityType, r.Name)
+}
+
+func (r *Resource) spec() map[string]interface{} {
+	spec, _ := r.Body[r.EntityType].(map[string]interface{})
+	return spec
+}
+
+// providerTypes are the verification provider types a verification template metric is measured with
+var providerTypes = []string{model.Prometheus, model.Datadog, model.NewRelic}
+
+// Graph holds the dependencies between the given resources. RolloutSpecs depend on their strategy,
+// strategies on the verification templates of their steps and background verification, and templates
+// on the verification providers of their metric types. References to resources outside the given ones are ignored.
+type Graph struct {
+	resources    []*Resource
+	dependencies [][]int
+}
+
+func NewGraph(resources []*Resource) (*Graph, error) {
+	indexes := map[string]int{}
+	previousIndexes := map[int]int{}
+	providersByType := map[string][]int{}
+
+	for i, resource := range resources {
+		// a resource given more than once is applied in order, and its references wait for the last one
+		if previous, found := indexes[resource.Key()]; found {
+			previousIndexes[i] = previous
+		}
+
+		indexes[resource.Key()] = i
+
+		if resource.EntityType == model.VerificationProviderEntity {
+			for _, providerType := range providerTypes {
+				if _, ok := resource.spec()[providerType]; ok {
+					providersByType[providerType] = append(providersByType[providerType], i)
+				}
+			}
+		}
+	}
+
+	graph := &Graph{resources: resources, dependencies: make([][]int, len(resources))}
+	for i, resource := range resources {
+		dependencies := map[int]bool{}
+		if previous, found := previousIndexes[i]; found {
+			dependencies[previous] = true
+		}
+
+		for _, reference := range references(resource) {
+			if dependency, found := indexes[reference]; found && dependency != i {
+				dependencies[dependency] = true
+			}
+		}
+
+		if resource.EntityType == model.VerificationTemplateEntity {
+			for _, providerType := range metricProviderTypes(resource.spec()) {
+				for _, dependency := range providersByType[providerType] {
+					dependencies[dependency] = true
+				}
+			}
+		}
+
+		for dependency := range dependencies {
+			graph.dependencies[i] = append(graph.dependencies[i], dependency)
+		}
+
+		sort.Ints(graph.dependencies[i])
+	}
+
+	if cycle := graph.findCycle(); cycle != nil {
+		keys := make([]string, len(cycle))
+		for i, index := range cycle {
+			keys[i] = resources[index].Key()
+		}
+
+		return nil, fmt.Errorf("error: Circular dependency between the resources %s", strings.Join(keys, " -> "))
+	}
+
+	return graph, nil
+}
+
+// Dependencies returns the keys of the given resources the resource depends on
+func (g *Graph) Dependencies(resource *Resource) []string {
+	for i := range g.resources {
+		if g.resources[i] == resource {
+			keys := make([]string, len(g.dependencies[i]))
+			for j, dependency := range g.dependencies[i] {
+				keys[j] = g.resources[dependency].Key()
+			}
+
+			return keys
+		}
+	}
+
+	return nil
+}
+
+func (g *Graph) findCycle() []int {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	states := make([]int, len(g.resources))
+	var path []int
+
+	var visit func(i int) []int
+	visit = func(i int) []int {
+		states[i] = visiting
+		path = append(path, i)
+
+		for _, dependency := range g.dependencies[i] {
+			switch states[dependency] {
+			case visiting:
+				for start := range path {
+					if path[start] == dependency {
+						return append(append([]int{}, path[start:]...), dependency)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dependency); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		states[i] = visited
+		path = path[:len(path)-1]
+
+		return nil
+	}
+
+	for i := range g.resources {
+		if states[i] == unvisited {
+			if cycle := visit(i); cycle != nil {
+				return cycle
+			}
+		}
+	}
+
+	return nil
+}
+
+// references lists the keys of the resources referenced by name
+func references(resource *Resource) []string {
+	spec := resource.spec()
+	var retVal []string
+
+	switch resource.EntityType {
+	case model.RolloutSpecEntity:
+		if strategyRef, ok := spec["strategy"].(map[string]interface{}); ok {
+			if name, ok := strategyRef["name"].(string); ok {
+				retVal = append(retVal, fmt.Sprintf("%s/%s", model.StrategyEntity, name))
+			}
+		}
+	case model.StrategyEntity:
+		for _, strategyType := range []string{model.CanaryStrategyType, model.RollingUpdateStrategyType} {
+			strategyEssence, ok := spec[strategyType].(map[string]interface{})
+			if ok == false {
+				continue
+			}
+
+			verifications := []interface{}{strategyEssence["backgroundVerification"]}
+			steps, _ := strategyEssence["steps"].([]interface{})
+			for _, step := range steps {
+				if step, ok := step.(map[string]interface{}); ok {
+					verifications = append(verifications, step["verification"])
+				}
+			}
+
+			for _, verification := range verifications {
+				for _, name := range templateNames(verification) {
+					retVal = append(retVal, fmt.Sprintf("%s/%s", model.VerificationTemplateEntity, name))
+				}
+			}
+		}
+	}
+
+	return retVal
+}
+
+func templateNames(verification interface{}) []string {
+	verificationDef, ok := verification.(map[string]interface{})
+	if ok == false {
+		return nil
+	}
+
+	var retVal []string
+	names, _ := verificationDef["templateNames"].([]interface{})
+	for _, name := range names {
+		if name, ok := name.(string); ok {
+			retVal = append(retVal, name)
+		}
+	}
+
+	return retVal
+}
+
+func metricProviderTypes(spec map[string]interface{}) []string {
+	var retVal []string
+	listed := map[string]bool{}
+
+	metrics, _ := spec["metrics"].([]interface{})
+	for _, metric := range metrics {
+		metricDef, _ := metric.(map[string]interface{})
+		providers, _ := metricDef["provider"].(map[string]interface{})
+
+		for _, providerType := range providerTypes {
+			if _, ok := providers[providerType]; ok && listed[providerType] == false {
+				retVal = append(retVal, providerType)
+				listed[providerType] = true
+			}
+		}
+	}
+
+	return retVal
+}
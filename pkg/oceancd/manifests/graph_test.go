@@ -0,0 +1,174 @@
+package manifests
+
+import (
+	"context"
+	"errors"
+	"github.com/google/go-cmp/cmp"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newResource(entityType string, name string, spec map[string]interface{}) *Resource {
+	spec["name"] = name
+	return &Resource{EntityType: entityType, Name: name, Body: map[string]interface{}{entityType: spec}, Source: "test.yaml"}
+}
+
+func newTestResources() []*Resource {
+	return []*Resource{
+		newResource("rolloutSpec", "checkout", map[string]interface{}{
+			"strategy": map[string]interface{}{"name": "canary"},
+		}),
+		newResource("strategy", "canary", map[string]interface{}{
+			"canary": map[string]interface{}{
+				"backgroundVerification": map[string]interface{}{"templateNames": []interface{}{"latency"}},
+				"steps": []interface{}{
+					map[string]interface{}{"name": "first", "verification": map[string]interface{}{"templateNames": []interface{}{"errors"}}},
+				},
+			},
+		}),
+		newResource("verificationTemplate", "latency", map[string]interface{}{
+			"metrics": []interface{}{map[string]interface{}{"provider": map[string]interface{}{"prometheus": map[string]interface{}{}}}},
+		}),
+		newResource("verificationTemplate", "errors", map[string]interface{}{
+			"metrics": []interface{}{map[string]interface{}{"provider": map[string]interface{}{"web": map[string]interface{}{}}}},
+		}),
+		newResource("verificationProvider", "prometheus", map[string]interface{}{"prometheus": map[string]interface{}{}}),
+		newResource("strategy", "unrelated", map[string]interface{}{"rolling": map[string]interface{}{}}),
+	}
+}
+
+func TestGraphDependencies(t *testing.T) {
+	resources := newTestResources()
+	graph, err := NewGraph(resources)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string][]string{
+		"rolloutSpec/checkout":            {"strategy/canary"},
+		"strategy/canary":                 {"verificationTemplate/latency", "verificationTemplate/errors"},
+		"verificationTemplate/latency":    {"verificationProvider/prometheus"},
+		"verificationTemplate/errors":     {},
+		"verificationProvider/prometheus": {},
+		"strategy/unrelated":              {},
+	}
+
+	actual := map[string][]string{}
+	for _, resource := range resources {
+		actual[resource.Key()] = graph.Dependencies(resource)
+	}
+
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("dependencies mismatch (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestGraphRunOrder(t *testing.T) {
+	for _, reverse := range []bool{false, true} {
+		resources := newTestResources()
+		graph, err := NewGraph(resources)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mutex := sync.Mutex{}
+		done := map[string]bool{}
+		graph.Run(context.Background(), 3, reverse, func(_ context.Context, resource *Resource) error {
+			time.Sleep(time.Millisecond)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			// a dependency is done first, or last in reverse
+			for _, dependency := range graph.Dependencies(resource) {
+				if done[dependency] == reverse {
+					t.Errorf("reverse %v: %s ran in the wrong order with its dependency %s", reverse, resource.Key(), dependency)
+				}
+			}
+
+			done[resource.Key()] = true
+			return nil
+		})
+
+		if len(done) != len(resources) {
+			t.Errorf("reverse %v: expected every resource to run, ran %v", reverse, done)
+		}
+	}
+}
+
+func TestGraphRunSkipsDependentsOfFailures(t *testing.T) {
+	graph, err := NewGraph(newTestResources())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := graph.Run(context.Background(), 2, false, func(_ context.Context, resource *Resource) error {
+		if resource.Key() == "verificationTemplate/latency" {
+			return errors.New("error: Invalid template")
+		}
+
+		return nil
+	})
+
+	type outcome struct {
+		Key       string
+		Err       string
+		SkippedBy string
+	}
+
+	expected := []outcome{
+		{Key: "rolloutSpec/checkout", SkippedBy: "strategy/canary"},
+		{Key: "strategy/canary", SkippedBy: "verificationTemplate/latency"},
+		{Key: "verificationTemplate/latency", Err: "error: Invalid template"},
+		{Key: "verificationTemplate/errors"},
+		{Key: "verificationProvider/prometheus"},
+		{Key: "strategy/unrelated"},
+	}
+
+	actual := make([]outcome, len(results))
+	for i, result := range results {
+		actual[i] = outcome{Key: result.Resource.Key(), SkippedBy: result.SkippedBy}
+		if result.Err != nil {
+			actual[i].Err = result.Err.Error()
+		}
+	}
+
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("results mismatch (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestGraphCycle(t *testing.T) {
+	resources := []*Resource{
+		newResource("strategy", "canary", map[string]interface{}{}),
+		newResource("rolloutSpec", "checkout", map[string]interface{}{"strategy": map[string]interface{}{"name": "canary"}}),
+	}
+
+	// a strategy never references a rollout spec, force one to check the cycle detection
+	graph, err := NewGraph(resources)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	graph.dependencies[0] = append(graph.dependencies[0], 1)
+	if cycle := graph.findCycle(); len(cycle) != 3 {
+		t.Errorf("expected a cycle through both resources, got %v", cycle)
+	}
+}
+
+func TestGraphDuplicatesRunInOrder(t *testing.T) {
+	resources := []*Resource{
+		newResource("verificationProvider", "prometheus", map[string]interface{}{"prometheus": map[string]interface{}{}}),
+		newResource("verificationProvider", "prometheus", map[string]interface{}{"prometheus": map[string]interface{}{}}),
+	}
+
+	graph, err := NewGraph(resources)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([]string{"verificationProvider/prometheus"}, graph.Dependencies(resources[1])); diff != "" {
+		t.Errorf("expected the second document to wait for the first one:\n%s", diff)
+	}
+}
@@ -0,0 +1,48 @@
+package manifests
+
+import (
+	"strings"
+)
+
+// NewLiveResources wraps the resources of a kind as listed by the API, the ones without a name are left out
+func NewLiveResources(entityType string, items []interface{}) []*Resource {
+	var retVal []*Resource
+
+	for _, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if ok == false {
+			continue
+		}
+
+		name, _ := fields["name"].(string)
+		if name == "" {
+			continue
+		}
+
+		retVal = append(retVal, &Resource{EntityType: entityType, Name: name, Body: map[string]interface{}{entityType: fields}})
+	}
+
+	return retVal
+}
+
+// PruneCandidates returns the live resources named with the prefix that are absent from the applied ones.
+// A live resource still referenced by an applied one is kept, as the SaaS would refuse to delete it anyway.
+func PruneCandidates(applied []*Resource, live []*Resource, prefix string) []*Resource {
+	declared := map[string]bool{}
+	for _, resource := range applied {
+		declared[resource.Key()] = true
+
+		for _, reference := range references(resource) {
+			declared[reference] = true
+		}
+	}
+
+	var retVal []*Resource
+	for _, resource := range live {
+		if strings.HasPrefix(resource.Name, prefix) && declared[resource.Key()] == false {
+			retVal = append(retVal, resource)
+		}
+	}
+
+	return retVal
+}
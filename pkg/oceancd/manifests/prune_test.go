@@ -0,0 +1,65 @@
+package manifests
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestNewLiveResources(t *testing.T) {
+	items := []interface{}{
+		map[string]interface{}{"name": "team-canary", "canary": map[string]interface{}{}},
+		map[string]interface{}{"canary": map[string]interface{}{}},
+		"unexpected",
+	}
+
+	live := NewLiveResources("strategy", items)
+	if len(live) != 1 {
+		t.Fatalf("expected a single live resource, got %d", len(live))
+	}
+
+	expected := &Resource{EntityType: "strategy", Name: "team-canary", Body: map[string]interface{}{"strategy": items[0]}}
+	if diff := cmp.Diff(expected, live[0]); diff != "" {
+		t.Errorf("live resource mismatch (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestPruneCandidates(t *testing.T) {
+	applied := []*Resource{
+		newResource("rolloutSpec", "team-checkout", map[string]interface{}{
+			"strategy": map[string]interface{}{"name": "team-shared"},
+		}),
+		newResource("strategy", "team-canary", map[string]interface{}{}),
+	}
+
+	live := append(NewLiveResources("strategy", []interface{}{
+		map[string]interface{}{"name": "team-canary"},
+		map[string]interface{}{"name": "team-removed"},
+		map[string]interface{}{"name": "team-shared"},
+		map[string]interface{}{"name": "other-removed"},
+	}), NewLiveResources("rolloutSpec", []interface{}{
+		map[string]interface{}{"name": "team-checkout"},
+		map[string]interface{}{"name": "team-canary"},
+	})...)
+
+	cases := map[string]struct {
+		prefix   string
+		expected []string
+	}{
+		"prefix":    {prefix: "team-", expected: []string{"strategy/team-removed", "rolloutSpec/team-canary"}},
+		"no prefix": {prefix: "", expected: []string{"strategy/team-removed", "strategy/other-removed", "rolloutSpec/team-canary"}},
+		"no match":  {prefix: "nobody-"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var actual []string
+			for _, resource := range PruneCandidates(applied, live, tc.prefix) {
+				actual = append(actual, resource.Key())
+			}
+
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("candidates mismatch (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
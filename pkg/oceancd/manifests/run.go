@@ -0,0 +1,110 @@
+package manifests
+
+import (
+	"context"
+	"sync"
+)
+
+const DefaultConcurrency = 4
+
+// Result is the outcome of a resource. A resource is skipped without running when one of the resources
+// it waits for failed or was skipped.
+type Result struct {
+	Resource  *Resource
+	Err       error
+	SkippedBy string
+}
+
+func (r Result) Failed() bool {
+	return r.Err != nil || r.SkippedBy != ""
+}
+
+// Run runs fn on every resource once the resources it waits for succeeded, up to concurrency at a time.
+// A resource waits for its dependencies, or in reverse for the resources depending on it, e.g. to delete them first.
+// The failure of a resource does not stop the independent ones, the results are in the order of the resources.
+func (g *Graph) Run(ctx context.Context, concurrency int, reverse bool, fn func(ctx context.Context, resource *Resource) error) []Result {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	waitsFor := g.dependencies
+	if reverse {
+		waitsFor = make([][]int, len(g.resources))
+		for i, dependencies := range g.dependencies {
+			for _, dependency := range dependencies {
+				waitsFor[dependency] = append(waitsFor[dependency], i)
+			}
+		}
+	}
+
+	waitedBy := make([][]int, len(g.resources))
+	remaining := make([]int, len(g.resources))
+	for i, awaited := range waitsFor {
+		remaining[i] = len(awaited)
+		for _, j := range awaited {
+			waitedBy[j] = append(waitedBy[j], i)
+		}
+	}
+
+	results := make([]Result, len(g.resources))
+	for i, resource := range g.resources {
+		results[i].Resource = resource
+	}
+
+	semaphore := make(chan struct{}, concurrency)
+	mutex := sync.Mutex{}
+	wg := sync.WaitGroup{}
+
+	var start func(i int)
+	var complete func(i int)
+
+	// complete releases the resources waiting for the given one, and must be called with the mutex held
+	complete = func(i int) {
+		for _, waiting := range waitedBy[i] {
+			if results[i].Failed() && results[waiting].SkippedBy == "" {
+				results[waiting].SkippedBy = g.resources[i].Key()
+			}
+
+			remaining[waiting]--
+			if remaining[waiting] > 0 {
+				continue
+			}
+
+			if results[waiting].SkippedBy != "" {
+				complete(waiting)
+			} else {
+				start(waiting)
+			}
+		}
+	}
+
+	start = func(i int) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			err := fn(ctx, g.resources[i])
+			<-semaphore
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			results[i].Err = err
+			complete(i)
+		}()
+	}
+
+	mutex.Lock()
+	for i := range g.resources {
+		if remaining[i] == 0 {
+			start(i)
+		}
+	}
+	mutex.Unlock()
+
+	wg.Wait()
+
+	return results
+}
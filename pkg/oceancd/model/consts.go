@@ -39,6 +39,9 @@ const (
 	CanaryStrategyType             = "canary"
 	RollingUpdateStrategyTypeLabel = "Rolling Update"
 	CanaryStrategyTypeLabel        = "Canary"
+
+	// StubCell stands for an empty value in the printed tables
+	StubCell = "--"
 )
 
 var (
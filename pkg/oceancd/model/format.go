@@ -0,0 +1,10 @@
+package model
+
+// Stub returns the value, or the stub cell when it is empty
+func Stub(value string) string {
+	if value == "" {
+		return StubCell
+	}
+
+	return value
+}
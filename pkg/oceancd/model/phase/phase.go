@@ -22,14 +22,20 @@ var UncompletedStatuses = []Status{Promoting, Paused, InProgress, Pending, Verif
 type Status string
 
 type Phase struct {
-	Name              string                      `json:"name"`
-	Status            Status                      `json:"status"`
-	StartTime         string                      `json:"startTime"`
-	PausedAt          string                      `json:"pausedAt"`
-	VerifiedAt        string                      `json:"verifiedAt"`
-	EndTime           string                      `json:"endTime"`
-	TrafficPercentage int                         `json:"trafficPercentage"`
-	Verifications     []verification.Verification `json:"verifications"`
+	Name              string                      `json:"name" yaml:"name"`
+	Status            Status                      `json:"status" yaml:"status"`
+	StartTime         string                      `json:"startTime" yaml:"startTime"`
+	PausedAt          string                      `json:"pausedAt" yaml:"pausedAt"`
+	VerifiedAt        string                      `json:"verifiedAt" yaml:"verifiedAt"`
+	EndTime           string                      `json:"endTime" yaml:"endTime"`
+	TrafficPercentage int                         `json:"trafficPercentage" yaml:"trafficPercentage"`
+	Verifications     []verification.Verification `json:"verifications" yaml:"verifications"`
+}
+
+// DeepCopy returns a copy sharing no slice with the phase
+func (p Phase) DeepCopy() Phase {
+	p.Verifications = verification.DeepCopyVerifications(p.Verifications)
+	return p
 }
 
 func (p *Phase) IsUncompleted() bool {
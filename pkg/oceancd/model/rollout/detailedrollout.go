@@ -1,10 +1,12 @@
 package rollout
 
 import (
+	"fmt"
 	oceancd "spot-oceancd-cli/pkg/oceancd/model"
 	"spot-oceancd-cli/pkg/oceancd/model/phase"
 	"spot-oceancd-cli/pkg/oceancd/model/strategy"
 	"spot-oceancd-cli/pkg/oceancd/model/verification"
+	"strings"
 )
 
 const (
@@ -28,8 +30,36 @@ const (
 
 var CompletedStatuses = []Status{Failed, Aborted, Finished, Canceled}
 
+var UnsuccessfulStatuses = []Status{Failed, Aborted, InvalidSpec}
+
+var PausedStatuses = []Status{Paused, ManualPaused, FailurePolicyPaused}
+
+var Statuses = []Status{Pending, InProgress, Paused, Failed, Aborted, Aborting, Finished, Canceled, ManualPaused,
+	ManualPausing, InvalidSpec, Deallocating, Verifying, FailurePolicyPaused, FailurePolicyPausing, BackgroundVerifying}
+
 type Status string
 
+// ParseStatus matches the raw status case-insensitively against the known statuses
+func ParseStatus(raw string) (Status, bool) {
+	for _, status := range Statuses {
+		if strings.EqualFold(string(status), raw) {
+			return status, true
+		}
+	}
+
+	return "", false
+}
+
+func (s Status) IsUnsuccessful() bool {
+	for _, unsuccessfulStatus := range UnsuccessfulStatuses {
+		if s == unsuccessfulStatus {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (s Status) IsCompleted() bool {
 	for _, completedStatus := range CompletedStatuses {
 		if s == completedStatus {
@@ -40,38 +70,55 @@ func (s Status) IsCompleted() bool {
 	return false
 }
 
+// IsPaused reports whether the rollout is paused, for any reason
+func (s Status) IsPaused() bool {
+	for _, pausedStatus := range PausedStatuses {
+		if s == pausedStatus {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsDone reports whether the rollout stopped progressing for good, either completed or with an invalid spec
+func (s Status) IsDone() bool {
+	return s.IsCompleted() || s.IsUnsuccessful()
+}
+
 type ReplicasInfo struct {
-	Desired    int `json:"desired"`
-	Ready      int `json:"ready"`
-	InProgress int `json:"inProgress"`
-	Failed     int `json:"failed"`
+	Desired    int `json:"desired" yaml:"desired"`
+	Ready      int `json:"ready" yaml:"ready"`
+	InProgress int `json:"inProgress" yaml:"inProgress"`
+	Failed     int `json:"failed" yaml:"failed"`
 }
 
 type VersionStatus struct {
-	Version           string       `json:"version"`
-	K8sService        string       `json:"k8sService"`
-	TrafficPercentage int          `json:"trafficPercentage"`
-	Replicas          ReplicasInfo `json:"replicas"`
+	Version           string       `json:"version" yaml:"version"`
+	K8sService        string       `json:"k8sService" yaml:"k8sService"`
+	TrafficPercentage int          `json:"trafficPercentage" yaml:"trafficPercentage"`
+	Replicas          ReplicasInfo `json:"replicas" yaml:"replicas"`
 }
 
 type Rollout struct {
-	Id                        string        `json:"id"`
-	Status                    Status        `json:"status"`
-	SpotDeployment            string        `json:"spotDeployment"`
-	OriginalRolloutId         string        `json:"originalRolloutId"`
-	NewRolloutId              string        `json:"newRolloutId"`
-	StartTime                 string        `json:"startTime"`
-	EndTime                   string        `json:"endTime"`
-	ClusterId                 string        `json:"clusterId"`
-	Namespace                 string        `json:"namespace"`
-	Strategy                  string        `json:"strategy"`
-	HasBackgroundVerification bool          `json:"hasBackgroundVerification"`
-	NewVersionStatus          VersionStatus `json:"newVersionStatus"`
-	StableVersionStatus       VersionStatus `json:"stableVersionStatus"`
+	Id                        string        `json:"id" yaml:"id"`
+	Status                    Status        `json:"status" yaml:"status"`
+	SpotDeployment            string        `json:"spotDeployment" yaml:"spotDeployment"`
+	OriginalRolloutId         string        `json:"originalRolloutId" yaml:"originalRolloutId"`
+	NewRolloutId              string        `json:"newRolloutId" yaml:"newRolloutId"`
+	StartTime                 string        `json:"startTime" yaml:"startTime"`
+	EndTime                   string        `json:"endTime" yaml:"endTime"`
+	ClusterId                 string        `json:"clusterId" yaml:"clusterId"`
+	Namespace                 string        `json:"namespace" yaml:"namespace"`
+	Strategy                  string        `json:"strategy" yaml:"strategy"`
+	HasBackgroundVerification bool          `json:"hasBackgroundVerification" yaml:"hasBackgroundVerification"`
+	NewVersionStatus          VersionStatus `json:"newVersionStatus" yaml:"newVersionStatus"`
+	StableVersionStatus       VersionStatus `json:"stableVersionStatus" yaml:"stableVersionStatus"`
 }
 
 type Strategy interface {
 	GetHeaderRouteMatchesBySteps() map[string][]strategy.Match
+	GetStepNames() []string
 }
 
 type Definition struct {
@@ -79,10 +126,30 @@ type Definition struct {
 }
 
 type DetailedRollout struct {
-	Rollout
-	Definition    Definition
-	Phases        []phase.Phase               `json:"phases"`
-	Verifications []verification.Verification `json:"verifications"`
+	Rollout       `yaml:",inline"`
+	Definition    Definition                  `json:"-" yaml:"-"`
+	Phases        []phase.Phase               `json:"phases" yaml:"phases"`
+	Verifications []verification.Verification `json:"verifications" yaml:"verifications"`
+}
+
+// DeepCopy returns a copy of the rollout whose phases and verifications can be adjusted for printing.
+// The strategy of the definition is shared, it is never modified once fetched.
+func (d *DetailedRollout) DeepCopy() *DetailedRollout {
+	if d == nil {
+		return nil
+	}
+
+	retVal := *d
+	retVal.Verifications = verification.DeepCopyVerifications(d.Verifications)
+
+	if d.Phases != nil {
+		retVal.Phases = make([]phase.Phase, len(d.Phases))
+		for i, rolloutPhase := range d.Phases {
+			retVal.Phases[i] = rolloutPhase.DeepCopy()
+		}
+	}
+
+	return &retVal
 }
 
 func (d *DetailedRollout) GetBackgroundVerifications() []verification.Verification {
@@ -95,3 +162,54 @@ func (d *DetailedRollout) GetBackgroundVerifications() []verification.Verificati
 	}
 	return backgroundVerifications
 }
+
+// ActivePhase returns the 1-based index of the first uncompleted phase
+func (d *DetailedRollout) ActivePhase() int {
+	for i, rolloutPhase := range d.Phases {
+		if rolloutPhase.IsUncompleted() {
+			return i + 1
+		}
+	}
+
+	return len(d.Phases)
+}
+
+// PhaseProgress returns the active phase out of all phases, e.g. 2/3, or only the phases number once completed
+func (d *DetailedRollout) PhaseProgress() string {
+	if d.Status.IsCompleted() {
+		return fmt.Sprintf("%d", len(d.Phases))
+	}
+
+	return fmt.Sprintf("%d/%d", d.ActivePhase(), len(d.Phases))
+}
+
+type RolloutDetails struct {
+	Id             string `header:"ID"`
+	SpotDeployment string `header:"SpotDeployment"`
+	ClusterId      string `header:"Cluster"`
+	Namespace      string `header:"Namespace"`
+	Strategy       string `header:"Strategy"`
+	Status         string `header:"Status"`
+	Phases         string `header:"Phases"`
+	StartTime      string `header:"Start Time"`
+	EndTime        string `header:"End Time"`
+}
+
+func ConvertToRolloutDetails(detailedRollout DetailedRollout) RolloutDetails {
+	phases := "--"
+	if len(detailedRollout.Phases) > 0 {
+		phases = detailedRollout.PhaseProgress()
+	}
+
+	return RolloutDetails{
+		Id:             detailedRollout.Id,
+		SpotDeployment: detailedRollout.SpotDeployment,
+		ClusterId:      detailedRollout.ClusterId,
+		Namespace:      detailedRollout.Namespace,
+		Strategy:       detailedRollout.Strategy,
+		Status:         string(detailedRollout.Status),
+		Phases:         phases,
+		StartTime:      detailedRollout.StartTime,
+		EndTime:        detailedRollout.EndTime,
+	}
+}
@@ -0,0 +1,51 @@
+package rollout
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"spot-oceancd-cli/pkg/oceancd/model/phase"
+	"spot-oceancd-cli/pkg/oceancd/model/verification"
+	"testing"
+)
+
+func TestDeepCopy(t *testing.T) {
+	original := &DetailedRollout{
+		Rollout: Rollout{Id: "rol-1", Status: InProgress},
+		Phases: []phase.Phase{
+			{Name: "first", Status: phase.FullPromoted, Verifications: []verification.Verification{
+				{MetricName: "latency", Status: verification.Successful, DataPoints: []verification.DataPoint{{Value: "0.1"}}},
+			}},
+			{Name: "second", Status: phase.Pending},
+		},
+		Verifications: []verification.Verification{{MetricName: "latency", Step: "first"}},
+	}
+
+	copied := original.DeepCopy()
+	if diff := cmp.Diff(original, copied); diff != "" {
+		t.Fatalf("copy mismatch (-original +copy):\n%s", diff)
+	}
+
+	copied.Phases[1].Status = phase.Dropped
+	copied.Phases[0].Verifications[0].Status = verification.Failed
+	copied.Phases[0].Verifications[0].DataPoints[0].Value = "0.9"
+	copied.Verifications[0].MetricName = "errors"
+
+	expected := &DetailedRollout{
+		Rollout: Rollout{Id: "rol-1", Status: InProgress},
+		Phases: []phase.Phase{
+			{Name: "first", Status: phase.FullPromoted, Verifications: []verification.Verification{
+				{MetricName: "latency", Status: verification.Successful, DataPoints: []verification.DataPoint{{Value: "0.1"}}},
+			}},
+			{Name: "second", Status: phase.Pending},
+		},
+		Verifications: []verification.Verification{{MetricName: "latency", Step: "first"}},
+	}
+
+	if diff := cmp.Diff(expected, original); diff != "" {
+		t.Errorf("the original changed with its copy (-expected +actual):\n%s", diff)
+	}
+
+	var empty *DetailedRollout
+	if empty.DeepCopy() != nil {
+		t.Errorf("expected the copy of a nil rollout to be nil")
+	}
+}
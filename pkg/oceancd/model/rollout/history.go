@@ -0,0 +1,124 @@
+package rollout
+
+import (
+	oceancd "spot-oceancd-cli/pkg/oceancd/model"
+	"strings"
+	"time"
+)
+
+const retryChainSeparator = " > "
+
+// HistoryEntry is a past rollout of a SpotDeployment, linked to the rollouts it retried or was retried by
+type HistoryEntry struct {
+	Id                string   `json:"id" yaml:"id"`
+	Status            Status   `json:"status" yaml:"status"`
+	Strategy          string   `json:"strategy" yaml:"strategy"`
+	NewVersion        string   `json:"newVersion" yaml:"newVersion"`
+	StableVersion     string   `json:"stableVersion" yaml:"stableVersion"`
+	StartTime         string   `json:"startTime" yaml:"startTime"`
+	EndTime           string   `json:"endTime" yaml:"endTime"`
+	Duration          string   `json:"duration" yaml:"duration"`
+	OriginalRolloutId string   `json:"originalRolloutId" yaml:"originalRolloutId"`
+	NewRolloutId      string   `json:"newRolloutId" yaml:"newRolloutId"`
+	RetryChain        []string `json:"retryChain,omitempty" yaml:"retryChain,omitempty"`
+}
+
+type HistoryDetails struct {
+	Id            string `header:"ID"`
+	NewVersion    string `header:"Version"`
+	StableVersion string `header:"Stable Version"`
+	Strategy      string `header:"Strategy"`
+	Status        string `header:"Status"`
+	StartTime     string `header:"Start Time"`
+	Duration      string `header:"Duration"`
+	RetryChain    string `header:"Retry Chain"`
+}
+
+// NewHistory converts the given rollouts, in their order, to history entries.
+// The retry chain of a rollout follows its OriginalRolloutId and NewRolloutId links through the given rollouts,
+// and ends with the first linked rollout that is not one of them.
+func NewHistory(rollouts []Rollout) []HistoryEntry {
+	rolloutsById := make(map[string]Rollout, len(rollouts))
+	for _, rolloutItem := range rollouts {
+		rolloutsById[rolloutItem.Id] = rolloutItem
+	}
+
+	history := make([]HistoryEntry, len(rollouts))
+	for i, rolloutItem := range rollouts {
+		history[i] = HistoryEntry{
+			Id:                rolloutItem.Id,
+			Status:            rolloutItem.Status,
+			Strategy:          rolloutItem.Strategy,
+			NewVersion:        rolloutItem.NewVersionStatus.Version,
+			StableVersion:     rolloutItem.StableVersionStatus.Version,
+			StartTime:         rolloutItem.StartTime,
+			EndTime:           rolloutItem.EndTime,
+			Duration:          Duration(rolloutItem.StartTime, rolloutItem.EndTime),
+			OriginalRolloutId: rolloutItem.OriginalRolloutId,
+			NewRolloutId:      rolloutItem.NewRolloutId,
+			RetryChain:        retryChain(rolloutItem, rolloutsById),
+		}
+	}
+
+	return history
+}
+
+func retryChain(rolloutItem Rollout, rolloutsById map[string]Rollout) []string {
+	visited := map[string]bool{rolloutItem.Id: true}
+
+	var originals []string
+	for current := rolloutItem; current.OriginalRolloutId != "" && visited[current.OriginalRolloutId] == false; {
+		originals = append([]string{current.OriginalRolloutId}, originals...)
+		visited[current.OriginalRolloutId] = true
+
+		original, found := rolloutsById[current.OriginalRolloutId]
+		if found == false {
+			break
+		}
+
+		current = original
+	}
+
+	chain := append(originals, rolloutItem.Id)
+	for current := rolloutItem; current.NewRolloutId != "" && visited[current.NewRolloutId] == false; {
+		chain = append(chain, current.NewRolloutId)
+		visited[current.NewRolloutId] = true
+
+		retry, found := rolloutsById[current.NewRolloutId]
+		if found == false {
+			break
+		}
+
+		current = retry
+	}
+
+	if len(chain) == 1 {
+		return nil
+	}
+
+	return chain
+}
+
+func ConvertToHistoryDetails(entry HistoryEntry) HistoryDetails {
+	return HistoryDetails{
+		Id:            entry.Id,
+		NewVersion:    oceancd.Stub(entry.NewVersion),
+		StableVersion: oceancd.Stub(entry.StableVersion),
+		Strategy:      entry.Strategy,
+		Status:        string(entry.Status),
+		StartTime:     oceancd.Stub(entry.StartTime),
+		Duration:      oceancd.Stub(entry.Duration),
+		RetryChain:    oceancd.Stub(strings.Join(entry.RetryChain, retryChainSeparator)),
+	}
+}
+
+// Duration is computed from the recorded times only, so an unfinished rollout has none
+func Duration(startTime string, endTime string) string {
+	start, startErr := time.Parse(time.RFC3339, startTime)
+	end, endErr := time.Parse(time.RFC3339, endTime)
+	if startErr != nil || endErr != nil || end.Before(start) {
+		return ""
+	}
+
+	return end.Sub(start).Round(time.Second).String()
+}
@@ -0,0 +1,61 @@
+package rollout
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestNewHistoryRetryChains(t *testing.T) {
+	rollouts := []Rollout{
+		{Id: "rol-c", OriginalRolloutId: "rol-b", Status: Finished},
+		{Id: "rol-b", OriginalRolloutId: "rol-a", NewRolloutId: "rol-c", Status: Failed},
+		{Id: "rol-single", Status: Finished},
+		{Id: "rol-a", OriginalRolloutId: "rol-beyond-limit", NewRolloutId: "rol-b", Status: Aborted},
+		{Id: "rol-loop", OriginalRolloutId: "rol-loop", NewRolloutId: "rol-loop", Status: Failed},
+	}
+
+	expected := map[string][]string{
+		"rol-c":      {"rol-beyond-limit", "rol-a", "rol-b", "rol-c"},
+		"rol-b":      {"rol-beyond-limit", "rol-a", "rol-b", "rol-c"},
+		"rol-single": nil,
+		"rol-a":      {"rol-beyond-limit", "rol-a", "rol-b", "rol-c"},
+		"rol-loop":   nil,
+	}
+
+	actual := map[string][]string{}
+	for _, entry := range NewHistory(rollouts) {
+		actual[entry.Id] = entry.RetryChain
+	}
+
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("retry chains mismatch (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestConvertToHistoryDetails(t *testing.T) {
+	entry := NewHistory([]Rollout{{
+		Id:                  "rol-a78dsds9s",
+		Status:              Finished,
+		Strategy:            "canary",
+		StartTime:           "2026-10-18T02:00:00Z",
+		EndTime:             "2026-10-18T02:47:12Z",
+		NewVersionStatus:    VersionStatus{Version: "v2"},
+		StableVersionStatus: VersionStatus{Version: ""},
+		NewRolloutId:        "rol-retry",
+	}})[0]
+
+	expected := HistoryDetails{
+		Id:            "rol-a78dsds9s",
+		NewVersion:    "v2",
+		StableVersion: "--",
+		Strategy:      "canary",
+		Status:        "finished",
+		StartTime:     "2026-10-18T02:00:00Z",
+		Duration:      "47m12s",
+		RetryChain:    "rol-a78dsds9s > rol-retry",
+	}
+
+	if diff := cmp.Diff(expected, ConvertToHistoryDetails(entry)); diff != "" {
+		t.Errorf("details mismatch (-expected +actual):\n%s", diff)
+	}
+}
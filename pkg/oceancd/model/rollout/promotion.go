@@ -0,0 +1,74 @@
+package rollout
+
+import (
+	"fmt"
+	"spot-oceancd-cli/pkg/oceancd/model/phase"
+	"strings"
+)
+
+// PromotedStatuses are the statuses of a phase the rollout moved on from
+var PromotedStatuses = []phase.Status{phase.Promoted, phase.FullPromoted, phase.Finished}
+
+// PlanPromotion returns the phases to promote, in order, to move the active phase of the rollout to toPhase,
+// or the given number of steps forward when toPhase is empty. The phases are the steps of the rollout strategy.
+func PlanPromotion(detailedRollout *DetailedRollout, stepNames []string, toPhase string, steps int) ([]string, error) {
+	if len(stepNames) == 0 {
+		return nil, fmt.Errorf("error: The strategy of rollout %s has no steps to promote", detailedRollout.Id)
+	}
+
+	active := 0
+	if len(detailedRollout.Phases) > 0 {
+		activePhase := detailedRollout.Phases[detailedRollout.ActivePhase()-1].Name
+
+		active = indexOf(stepNames, activePhase)
+		if active < 0 {
+			return nil, fmt.Errorf("error: Active phase %s is not a step of the rollout strategy", activePhase)
+		}
+	}
+
+	target := active + steps
+	if toPhase != "" {
+		target = indexOf(stepNames, toPhase)
+		if target < 0 {
+			return nil, fmt.Errorf("error: Unknown phase %s. The phases of the rollout are: %s", toPhase, strings.Join(stepNames, ", "))
+		}
+
+		if target <= active {
+			return nil, fmt.Errorf("error: Rollout %s already reached phase %s", detailedRollout.Id, toPhase)
+		}
+	} else if target >= len(stepNames) {
+		return nil, fmt.Errorf("error: Cannot promote %d steps from phase %s, the rollout has %d more phases",
+			steps, stepNames[active], len(stepNames)-active-1)
+	}
+
+	return stepNames[active:target], nil
+}
+
+// IsPhasePromoted reports whether the rollout moved on from the given phase
+func (d *DetailedRollout) IsPhasePromoted(phaseName string) bool {
+	for _, rolloutPhase := range d.Phases {
+		if rolloutPhase.Name != phaseName {
+			continue
+		}
+
+		for _, promotedStatus := range PromotedStatuses {
+			if rolloutPhase.Status == promotedStatus {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return false
+}
+
+func indexOf(values []string, value string) int {
+	for i := range values {
+		if values[i] == value {
+			return i
+		}
+	}
+
+	return -1
+}
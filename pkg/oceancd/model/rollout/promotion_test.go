@@ -0,0 +1,81 @@
+package rollout
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"spot-oceancd-cli/pkg/oceancd/model/phase"
+	"testing"
+)
+
+func TestPlanPromotion(t *testing.T) {
+	stepNames := []string{"first-phase", "second-phase", "third-phase", "fourth-phase"}
+	inSecondPhase := &DetailedRollout{
+		Rollout: Rollout{Id: "rol-a78dsds9s", Status: Paused},
+		Phases: []phase.Phase{
+			{Name: "first-phase", Status: phase.Promoted},
+			{Name: "second-phase", Status: phase.Paused},
+		},
+	}
+
+	cases := map[string]struct {
+		detailedRollout *DetailedRollout
+		stepNames       []string
+		toPhase         string
+		steps           int
+		expected        []string
+		expectedErr     string
+	}{
+		"to a later phase": {
+			detailedRollout: inSecondPhase, stepNames: stepNames, toPhase: "fourth-phase",
+			expected: []string{"second-phase", "third-phase"},
+		},
+		"to the next phase": {
+			detailedRollout: inSecondPhase, stepNames: stepNames, toPhase: "third-phase",
+			expected: []string{"second-phase"},
+		},
+		"by steps": {
+			detailedRollout: inSecondPhase, stepNames: stepNames, steps: 2,
+			expected: []string{"second-phase", "third-phase"},
+		},
+		"before the first phase started": {
+			detailedRollout: &DetailedRollout{Rollout: Rollout{Id: "rol-a78dsds9s"}}, stepNames: stepNames, steps: 1,
+			expected: []string{"first-phase"},
+		},
+		"to a reached phase": {
+			detailedRollout: inSecondPhase, stepNames: stepNames, toPhase: "second-phase",
+			expectedErr: "error: Rollout rol-a78dsds9s already reached phase second-phase",
+		},
+		"to an unknown phase": {
+			detailedRollout: inSecondPhase, stepNames: stepNames, toPhase: "fifth-phase",
+			expectedErr: "error: Unknown phase fifth-phase. The phases of the rollout are: first-phase, second-phase, third-phase, fourth-phase",
+		},
+		"beyond the last phase": {
+			detailedRollout: inSecondPhase, stepNames: stepNames, steps: 3,
+			expectedErr: "error: Cannot promote 3 steps from phase second-phase, the rollout has 2 more phases",
+		},
+		"without steps": {
+			detailedRollout: inSecondPhase, stepNames: nil, steps: 1,
+			expectedErr: "error: The strategy of rollout rol-a78dsds9s has no steps to promote",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := PlanPromotion(tc.detailedRollout, tc.stepNames, tc.toPhase, tc.steps)
+			if tc.expectedErr != "" {
+				if err == nil || err.Error() != tc.expectedErr {
+					t.Fatalf("expected error %q, got %v", tc.expectedErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("promoted phases mismatch (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
@@ -28,6 +28,23 @@ func (r *RollingUpdateStrategy) GetHeaderRouteMatchesBySteps() map[string][]Matc
 	return matches
 }
 
+func (c *CanaryStrategy) GetStepNames() []string {
+	return stepNames(c.Steps)
+}
+
+func (r *RollingUpdateStrategy) GetStepNames() []string {
+	return stepNames(r.Steps)
+}
+
+func stepNames(steps []Step) []string {
+	names := make([]string, len(steps))
+	for i, step := range steps {
+		names[i] = step.Name
+	}
+
+	return names
+}
+
 type Step struct {
 	Name           string         `json:"name"`
 	SetHeaderRoute SetHeaderRoute `json:"setHeaderRoute"`
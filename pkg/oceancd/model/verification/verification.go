@@ -18,21 +18,66 @@ var StatusOrder = map[Status]int{
 type Status string
 
 type Verification struct {
-	MetricName       string      `json:"metricName"`
-	StartTime        string      `json:"startTime"`
-	Status           Status      `json:"status"`
-	FailureCondition string      `json:"failureCondition"`
-	Query            string      `json:"query"`
-	FailureLimit     int         `json:"failureLimit"`
-	Interval         string      `json:"interval"`
-	Count            int         `json:"count"`
-	DataPoints       []DataPoint `json:"dataPoints"`
-	Provider         string      `json:"provider"`
-	Step             string      `json:"step"`
+	MetricName       string      `json:"metricName" yaml:"metricName"`
+	StartTime        string      `json:"startTime" yaml:"startTime"`
+	Status           Status      `json:"status" yaml:"status"`
+	FailureCondition string      `json:"failureCondition" yaml:"failureCondition"`
+	Query            string      `json:"query" yaml:"query"`
+	FailureLimit     int         `json:"failureLimit" yaml:"failureLimit"`
+	Interval         string      `json:"interval" yaml:"interval"`
+	Count            int         `json:"count" yaml:"count"`
+	DataPoints       []DataPoint `json:"dataPoints" yaml:"dataPoints"`
+	Provider         string      `json:"provider" yaml:"provider"`
+	Step             string      `json:"step" yaml:"step"`
 }
 
 type DataPoint struct {
-	Timestamp string `json:"timestamp"`
-	Value     string `json:"value"`
-	Status    string `json:"status"`
+	Timestamp string `json:"timestamp" yaml:"timestamp"`
+	Value     string `json:"value" yaml:"value"`
+	Status    string `json:"status" yaml:"status"`
+}
+
+// DeepCopy returns a copy sharing no slice with the verification
+func (v Verification) DeepCopy() Verification {
+	if v.DataPoints != nil {
+		v.DataPoints = append(make([]DataPoint, 0, len(v.DataPoints)), v.DataPoints...)
+	}
+
+	return v
+}
+
+// DeepCopyVerifications copies every verification, a nil slice stays nil
+func DeepCopyVerifications(verifications []Verification) []Verification {
+	if verifications == nil {
+		return nil
+	}
+
+	retVal := make([]Verification, len(verifications))
+	for i, verificationItem := range verifications {
+		retVal[i] = verificationItem.DeepCopy()
+	}
+
+	return retVal
+}
+
+// FailedMeasurements counts the data points measured as failed
+func (v *Verification) FailedMeasurements() int {
+	failed := 0
+	for _, dataPoint := range v.DataPoints {
+		if Status(dataPoint.Status) == Failed {
+			failed++
+		}
+	}
+
+	return failed
+}
+
+// RemainingFailures returns how many more failed measurements are tolerated before the verification fails
+func (v *Verification) RemainingFailures() int {
+	remaining := v.FailureLimit - v.FailedMeasurements()
+	if remaining < 0 {
+		return 0
+	}
+
+	return remaining
 }
@@ -0,0 +1,229 @@
+package repositories
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"spot-oceancd-cli/pkg/oceancd"
+	"spot-oceancd-cli/pkg/oceancd/model/phase"
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+	strategymodel "spot-oceancd-cli/pkg/oceancd/model/strategy"
+	"spot-oceancd-cli/pkg/oceancd/model/verification"
+	"sync"
+	"time"
+)
+
+// Snapshot is a rollout as it was observed at a point in time, one json line of a recorded session
+type Snapshot struct {
+	ObservedAt time.Time                `json:"observedAt"`
+	Rollout    *rollout.DetailedRollout `json:"rollout"`
+	Definition map[string]interface{}   `json:"definition,omitempty"`
+}
+
+// SessionRecorder appends every distinct rollout it is given to a session file
+type SessionRecorder struct {
+	mutex        sync.Mutex
+	writer       io.WriteCloser
+	lastRecorded []byte
+}
+
+func NewSessionRecorder(path string) (*SessionRecorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error: Failed to create the session file %s - %w", path, err)
+	}
+
+	return &SessionRecorder{writer: file}, nil
+}
+
+// Record writes the rollout unless it did not change since the previously recorded one
+func (r *SessionRecorder) Record(detailedRollout *rollout.DetailedRollout, observedAt time.Time) error {
+	rolloutBytes, err := json.Marshal(detailedRollout)
+	if err != nil {
+		return err
+	}
+
+	definition := definitionOf(detailedRollout.Definition.Strategy)
+	definitionBytes, err := json.Marshal(definition)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// the observed time is left out of the comparison, so an unchanged rollout is only recorded once
+	current := append(rolloutBytes, definitionBytes...)
+	if bytes.Equal(current, r.lastRecorded) {
+		return nil
+	}
+
+	line, err := json.Marshal(Snapshot{ObservedAt: observedAt, Rollout: detailedRollout, Definition: definition})
+	if err != nil {
+		return err
+	}
+
+	if _, err = r.writer.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("error: Failed to record the rollout %s - %w", detailedRollout.Id, err)
+	}
+
+	r.lastRecorded = current
+
+	return nil
+}
+
+func (r *SessionRecorder) Close() error {
+	return r.writer.Close()
+}
+
+// definitionOf turns the strategy back into the rollout definition it is parsed from, see StrategyFromDefinition
+func definitionOf(strategy rollout.Strategy) map[string]interface{} {
+	switch strategy.(type) {
+	case *strategymodel.CanaryStrategy:
+		return map[string]interface{}{"strategy": map[string]interface{}{"canary": strategy}}
+	case *strategymodel.RollingUpdateStrategy:
+		return map[string]interface{}{"strategy": map[string]interface{}{"rolling": strategy}}
+	default:
+		return nil
+	}
+}
+
+// ReadSession reads the snapshots of a recorded session, in the order they were observed
+func ReadSession(path string) ([]Snapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error: Failed to open the session file %s - %w", path, err)
+	}
+	defer file.Close()
+
+	var snapshots []Snapshot
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		snapshot := Snapshot{}
+		if err = json.Unmarshal(line, &snapshot); err != nil || snapshot.Rollout == nil {
+			return nil, fmt.Errorf("error: Invalid snapshot at line %d of %s", lineNumber, path)
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error: Failed to read the session file %s - %w", path, err)
+	}
+
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("error: The session file %s has no snapshots", path)
+	}
+
+	return snapshots, nil
+}
+
+// ReplayRolloutRepository serves the snapshot a recorded session is positioned at.
+// A replayed rollout is read only, so sending it an action fails.
+type ReplayRolloutRepository struct {
+	mutex     sync.Mutex
+	snapshots []Snapshot
+	position  int
+}
+
+func NewReplayRolloutRepository(snapshots []Snapshot) *ReplayRolloutRepository {
+	return &ReplayRolloutRepository{snapshots: snapshots}
+}
+
+// Snapshots returns every snapshot of the session
+func (r *ReplayRolloutRepository) Snapshots() []Snapshot {
+	return r.snapshots
+}
+
+// Seek positions the session at the snapshot of the given index
+func (r *ReplayRolloutRepository) Seek(index int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.position = index
+}
+
+func (r *ReplayRolloutRepository) GetRollout(_ context.Context, rolloutId string) (rollout.Rollout, error) {
+	snapshot, err := r.current(rolloutId)
+	if err != nil {
+		return rollout.Rollout{}, err
+	}
+
+	return snapshot.Rollout.Rollout, nil
+}
+
+func (r *ReplayRolloutRepository) GetRolloutPhases(_ context.Context, rolloutId string) ([]phase.Phase, error) {
+	snapshot, err := r.current(rolloutId)
+	if err != nil {
+		return nil, err
+	}
+
+	// a copy, since the views adjust the phases they print
+	return append([]phase.Phase{}, snapshot.Rollout.Phases...), nil
+}
+
+func (r *ReplayRolloutRepository) GetRolloutVerifications(_ context.Context, rolloutId string) ([]verification.Verification, error) {
+	snapshot, err := r.current(rolloutId)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]verification.Verification{}, snapshot.Rollout.Verifications...), nil
+}
+
+func (r *ReplayRolloutRepository) GetRolloutDefinition(_ context.Context, rolloutId string) (map[string]interface{}, error) {
+	snapshot, err := r.current(rolloutId)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot.Definition, nil
+}
+
+func (r *ReplayRolloutRepository) GetStrategy(ctx context.Context, rolloutId string) (rollout.Strategy, error) {
+	rolloutDefinition, err := r.GetRolloutDefinition(ctx, rolloutId)
+	if err != nil || rolloutDefinition == nil {
+		return nil, err
+	}
+
+	return StrategyFromDefinition(rolloutDefinition)
+}
+
+func (r *ReplayRolloutRepository) ListRollouts(_ context.Context, filter oceancd.RolloutFilter) ([]rollout.Rollout, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	rollouts := make([]rollout.Rollout, 0, 1)
+	if rolloutItem := r.snapshots[r.position].Rollout.Rollout; filter.Matches(rolloutItem) {
+		rollouts = append(rollouts, rolloutItem)
+	}
+
+	return rollouts, nil
+}
+
+func (r *ReplayRolloutRepository) SendRolloutAction(_ context.Context, rolloutId string, body map[string]string) error {
+	return fmt.Errorf("error: Cannot %s the rollout %s of a replayed session", body["action"], rolloutId)
+}
+
+func (r *ReplayRolloutRepository) current(rolloutId string) (Snapshot, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	snapshot := r.snapshots[r.position]
+	if snapshot.Rollout.Id != rolloutId {
+		return Snapshot{}, fmt.Errorf("error: Rollout %s was not recorded", rolloutId)
+	}
+
+	return snapshot, nil
+}
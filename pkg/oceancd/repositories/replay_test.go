@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+	"github.com/google/go-cmp/cmp"
+	"path/filepath"
+	"spot-oceancd-cli/pkg/oceancd/model/phase"
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+	"spot-oceancd-cli/pkg/oceancd/model/strategy"
+	"testing"
+	"time"
+)
+
+func TestSessionRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	recorder, err := NewSessionRecorder(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	canary := &strategy.CanaryStrategy{Steps: []strategy.Step{{Name: "first"}, {Name: "second"}}}
+	observedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	states := []rollout.DetailedRollout{
+		{Rollout: rollout.Rollout{Id: "rol-a78dsds9s", Status: rollout.InProgress}, Phases: []phase.Phase{{Name: "first", Status: phase.InProgress}}},
+		{Rollout: rollout.Rollout{Id: "rol-a78dsds9s", Status: rollout.InProgress}, Phases: []phase.Phase{{Name: "first", Status: phase.InProgress}}},
+		{Rollout: rollout.Rollout{Id: "rol-a78dsds9s", Status: rollout.Finished}, Phases: []phase.Phase{{Name: "first", Status: phase.Finished}}},
+	}
+
+	for i := range states {
+		states[i].Definition.Strategy = canary
+		if err = recorder.Record(&states[i], observedAt.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshots, err := ReadSession(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the unchanged second state is not recorded
+	expectedTimes := []time.Time{observedAt, observedAt.Add(2 * time.Minute)}
+	actualTimes := make([]time.Time, len(snapshots))
+	for i, snapshot := range snapshots {
+		actualTimes[i] = snapshot.ObservedAt
+	}
+
+	if diff := cmp.Diff(expectedTimes, actualTimes); diff != "" {
+		t.Fatalf("observed times mismatch (-expected +actual):\n%s", diff)
+	}
+
+	session := NewReplayRolloutRepository(snapshots)
+	session.Seek(1)
+
+	rolloutItem, err := session.GetRollout(context.Background(), "rol-a78dsds9s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rolloutItem.Status != rollout.Finished {
+		t.Errorf("expected the status of the second snapshot, got %s", rolloutItem.Status)
+	}
+
+	replayedStrategy, err := session.GetStrategy(context.Background(), "rol-a78dsds9s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(rollout.Strategy(canary), replayedStrategy); diff != "" {
+		t.Errorf("strategy mismatch (-expected +actual):\n%s", diff)
+	}
+
+	if _, err = session.GetRollout(context.Background(), "rol-other"); err == nil {
+		t.Errorf("expected an error for a rollout that was not recorded")
+	}
+}
@@ -0,0 +1,183 @@
+package repositoriestest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"spot-oceancd-cli/pkg/oceancd"
+	"spot-oceancd-cli/pkg/oceancd/model/phase"
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+	"spot-oceancd-cli/pkg/oceancd/model/verification"
+	"spot-oceancd-cli/pkg/oceancd/repositories"
+	"sync"
+)
+
+// FakeRolloutRepository keeps the rollouts in memory, so the view controllers and the rollout commands
+// run without network. Every method fails with the error set by FailWith, and unknown rollouts are not found.
+type FakeRolloutRepository struct {
+	mutex       sync.Mutex
+	rolloutIds  []string
+	rollouts    map[string]rollout.DetailedRollout
+	definitions map[string]map[string]interface{}
+	errors      map[string]error
+	actions     []FakeRolloutAction
+}
+
+// FakeRolloutAction is an action sent to a FakeRolloutRepository
+type FakeRolloutAction struct {
+	RolloutId string
+	Action    string
+}
+
+func NewFakeRolloutRepository() *FakeRolloutRepository {
+	return &FakeRolloutRepository{
+		rollouts:    map[string]rollout.DetailedRollout{},
+		definitions: map[string]map[string]interface{}{},
+		errors:      map[string]error{},
+	}
+}
+
+// PutRollout adds the rollout along with its definition, or replaces it when it exists
+func (r *FakeRolloutRepository) PutRollout(detailedRollout rollout.DetailedRollout, definition map[string]interface{}) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.rollouts[detailedRollout.Id]; ok == false {
+		r.rolloutIds = append(r.rolloutIds, detailedRollout.Id)
+	}
+
+	r.rollouts[detailedRollout.Id] = detailedRollout
+	r.definitions[detailedRollout.Id] = definition
+}
+
+// FailWith makes the given method, e.g. "GetRolloutPhases", fail with the error. A nil error clears the failure.
+func (r *FakeRolloutRepository) FailWith(method string, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err == nil {
+		delete(r.errors, method)
+		return
+	}
+
+	r.errors[method] = err
+}
+
+// Actions returns the actions sent so far, in order
+func (r *FakeRolloutRepository) Actions() []FakeRolloutAction {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]FakeRolloutAction{}, r.actions...)
+}
+
+func (r *FakeRolloutRepository) GetRollout(_ context.Context, rolloutId string) (rollout.Rollout, error) {
+	detailedRollout, err := r.get("GetRollout", rolloutId)
+	if err != nil {
+		return rollout.Rollout{}, err
+	}
+
+	return detailedRollout.Rollout, nil
+}
+
+func (r *FakeRolloutRepository) GetRolloutPhases(_ context.Context, rolloutId string) ([]phase.Phase, error) {
+	detailedRollout, err := r.get("GetRolloutPhases", rolloutId)
+	if err != nil {
+		return nil, err
+	}
+
+	// a copy, since the views adjust the phases they print
+	return append([]phase.Phase{}, detailedRollout.Phases...), nil
+}
+
+func (r *FakeRolloutRepository) GetRolloutVerifications(_ context.Context, rolloutId string) ([]verification.Verification, error) {
+	detailedRollout, err := r.get("GetRolloutVerifications", rolloutId)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]verification.Verification{}, detailedRollout.Verifications...), nil
+}
+
+func (r *FakeRolloutRepository) GetRolloutDefinition(_ context.Context, rolloutId string) (map[string]interface{}, error) {
+	if _, err := r.get("GetRolloutDefinition", rolloutId); err != nil {
+		return nil, err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.definitions[rolloutId], nil
+}
+
+func (r *FakeRolloutRepository) GetStrategy(ctx context.Context, rolloutId string) (rollout.Strategy, error) {
+	if err := r.failure("GetStrategy"); err != nil {
+		return nil, err
+	}
+
+	rolloutDefinition, err := r.GetRolloutDefinition(ctx, rolloutId)
+	if err != nil {
+		return nil, err
+	}
+
+	return repositories.StrategyFromDefinition(rolloutDefinition)
+}
+
+func (r *FakeRolloutRepository) ListRollouts(_ context.Context, filter oceancd.RolloutFilter) ([]rollout.Rollout, error) {
+	if err := r.failure("ListRollouts"); err != nil {
+		return nil, err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	rollouts := make([]rollout.Rollout, 0)
+	for _, rolloutId := range r.rolloutIds {
+		if rolloutItem := r.rollouts[rolloutId].Rollout; filter.Matches(rolloutItem) {
+			rollouts = append(rollouts, rolloutItem)
+		}
+	}
+
+	return rollouts, nil
+}
+
+func (r *FakeRolloutRepository) SendRolloutAction(_ context.Context, rolloutId string, body map[string]string) error {
+	if _, err := r.get("SendRolloutAction", rolloutId); err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.actions = append(r.actions, FakeRolloutAction{RolloutId: rolloutId, Action: body["action"]})
+
+	return nil
+}
+
+func (r *FakeRolloutRepository) failure(method string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.errors[method]
+}
+
+func (r *FakeRolloutRepository) get(method string, rolloutId string) (rollout.DetailedRollout, error) {
+	if err := r.failure(method); err != nil {
+		return rollout.DetailedRollout{}, err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	detailedRollout, ok := r.rollouts[rolloutId]
+	if ok == false {
+		return rollout.DetailedRollout{}, &oceancd.APIError{
+			StatusCode: http.StatusNotFound,
+			Code:       "NOT_FOUND",
+			Messages:   []string{fmt.Sprintf("Rollout %s does not exist", rolloutId)},
+		}
+	}
+
+	// every fetch returns fresh slices, as the api does
+	return *detailedRollout.DeepCopy(), nil
+}
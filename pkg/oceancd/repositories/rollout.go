@@ -1,23 +1,73 @@
 package repositories
 
 import (
+	"context"
 	"encoding/json"
 	"spot-oceancd-cli/pkg/oceancd"
+	"spot-oceancd-cli/pkg/oceancd/model/phase"
 	"spot-oceancd-cli/pkg/oceancd/model/rollout"
 	strategymodel "spot-oceancd-cli/pkg/oceancd/model/strategy"
+	"spot-oceancd-cli/pkg/oceancd/model/verification"
 )
 
-func NewRolloutRepository() *RolloutRepository {
-	return &RolloutRepository{}
+// RolloutRepository is the source of the rollouts and the target of their actions.
+// The Ocean CD api is the default backend, other ones can be swapped in, e.g. in tests.
+type RolloutRepository interface {
+	GetRollout(ctx context.Context, rolloutId string) (rollout.Rollout, error)
+	GetRolloutPhases(ctx context.Context, rolloutId string) ([]phase.Phase, error)
+	GetRolloutVerifications(ctx context.Context, rolloutId string) ([]verification.Verification, error)
+	GetRolloutDefinition(ctx context.Context, rolloutId string) (map[string]interface{}, error)
+	GetStrategy(ctx context.Context, rolloutId string) (rollout.Strategy, error)
+	ListRollouts(ctx context.Context, filter oceancd.RolloutFilter) ([]rollout.Rollout, error)
+	SendRolloutAction(ctx context.Context, rolloutId string, body map[string]string) error
 }
 
-type RolloutRepository struct {
+func NewRolloutRepository(client *oceancd.Client) RolloutRepository {
+	return &HttpRolloutRepository{client: client}
 }
 
-func (r *RolloutRepository) GetStrategy(rolloutId string) (rollout.Strategy, error) {
+// HttpRolloutRepository fetches the rollouts from the Ocean CD api
+type HttpRolloutRepository struct {
+	client *oceancd.Client
+}
+
+func (r *HttpRolloutRepository) GetRollout(ctx context.Context, rolloutId string) (rollout.Rollout, error) {
+	return r.client.GetRollout(ctx, rolloutId)
+}
+
+func (r *HttpRolloutRepository) GetRolloutPhases(ctx context.Context, rolloutId string) ([]phase.Phase, error) {
+	return r.client.GetRolloutPhases(ctx, rolloutId)
+}
+
+func (r *HttpRolloutRepository) GetRolloutVerifications(ctx context.Context, rolloutId string) ([]verification.Verification, error) {
+	return r.client.GetRolloutVerifications(ctx, rolloutId)
+}
+
+func (r *HttpRolloutRepository) GetRolloutDefinition(ctx context.Context, rolloutId string) (map[string]interface{}, error) {
+	return r.client.GetRolloutDefinition(ctx, rolloutId)
+}
+
+func (r *HttpRolloutRepository) GetStrategy(ctx context.Context, rolloutId string) (rollout.Strategy, error) {
+	rolloutDefinition, err := r.client.GetRolloutDefinition(ctx, rolloutId)
+	if err != nil {
+		return nil, err
+	}
+
+	return StrategyFromDefinition(rolloutDefinition)
+}
+
+func (r *HttpRolloutRepository) ListRollouts(ctx context.Context, filter oceancd.RolloutFilter) ([]rollout.Rollout, error) {
+	return r.client.ListRollouts(ctx, filter)
+}
+
+func (r *HttpRolloutRepository) SendRolloutAction(ctx context.Context, rolloutId string, body map[string]string) error {
+	return r.client.SendRolloutAction(ctx, rolloutId, body)
+}
+
+// StrategyFromDefinition parses the canary or rolling strategy of a rollout definition
+func StrategyFromDefinition(rolloutDefinition map[string]interface{}) (rollout.Strategy, error) {
 	var retVal rollout.Strategy
 	strategyDefinition := map[string]interface{}{}
-	rolloutDefinition, err := oceancd.GetRolloutDefinition(rolloutId)
 
 	if strategyInfo, ok := rolloutDefinition["strategy"]; ok {
 
@@ -0,0 +1,169 @@
+package oceancd
+
+import (
+	"fmt"
+	"github.com/go-resty/resty/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultMaxRetries       = 3
+	DefaultRetryWaitTime    = time.Second
+	DefaultRetryMaxWaitTime = 30 * time.Second
+)
+
+// RetriedRequest summarizes the failed attempts made for a single api endpoint
+type RetriedRequest struct {
+	Method         string
+	Url            string
+	FailedAttempts int
+	LastReason     string
+}
+
+type retryRecorder struct {
+	mutex    sync.Mutex
+	order    []string
+	requests map[string]*RetriedRequest
+}
+
+func newRetryRecorder() *retryRecorder {
+	return &retryRecorder{requests: map[string]*RetriedRequest{}}
+}
+
+func (r *retryRecorder) record(response *resty.Response, err error) {
+	if response == nil || response.Request == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := fmt.Sprintf("%s %s", response.Request.Method, response.Request.URL)
+	retried, exists := r.requests[key]
+	if exists == false {
+		retried = &RetriedRequest{Method: response.Request.Method, Url: response.Request.URL}
+		r.requests[key] = retried
+		r.order = append(r.order, key)
+	}
+
+	retried.FailedAttempts++
+
+	if err != nil {
+		retried.LastReason = err.Error()
+	} else {
+		retried.LastReason = response.Status()
+	}
+}
+
+func (r *retryRecorder) list() []RetriedRequest {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	retVal := make([]RetriedRequest, 0, len(r.order))
+	for _, key := range r.order {
+		retVal = append(retVal, *r.requests[key])
+	}
+
+	return retVal
+}
+
+// RetriedRequests returns every endpoint that needed at least one retry during the client lifetime
+func (c *Client) RetriedRequests() []RetriedRequest {
+	return c.retries.list()
+}
+
+func configureRetries(httpClient *resty.Client, options ClientOptions, recorder *retryRecorder) {
+	if options.MaxRetries <= 0 {
+		return
+	}
+
+	waitTime := options.RetryWaitTime
+	if waitTime <= 0 {
+		waitTime = DefaultRetryWaitTime
+	}
+
+	maxWaitTime := DefaultRetryMaxWaitTime
+	if waitTime > maxWaitTime {
+		maxWaitTime = waitTime
+	}
+
+	httpClient.
+		SetLogger(discardLogger{}).
+		SetRetryCount(options.MaxRetries).
+		SetRetryWaitTime(waitTime).
+		SetRetryMaxWaitTime(maxWaitTime).
+		SetRetryAfter(retryAfter).
+		AddRetryCondition(isRetryable).
+		AddRetryHook(recorder.record)
+}
+
+// isRetryable retries the rate limited, failed and server errored requests that are safe to send twice.
+// Changes, such as rollout actions, are never retried: the api does not deduplicate them, and even a rate limited
+// or timed out promote may have been applied already.
+func isRetryable(response *resty.Response, err error) bool {
+	if response == nil || response.Request == nil {
+		return false
+	}
+
+	// a canceled or timed out request context would fail every further attempt as well
+	if err != nil && response.Request.Context().Err() != nil {
+		return false
+	}
+
+	if isIdempotent(response.Request) == false {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	switch response.StatusCode() {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func isIdempotent(request *resty.Request) bool {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter honors the Retry-After header, either in seconds or as an http date.
+// Returning zero falls back to the exponential backoff.
+func retryAfter(_ *resty.Client, response *resty.Response) (time.Duration, error) {
+	header := response.Header().Get("Retry-After")
+	if header == "" {
+		return 0, nil
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	if date, err := http.ParseTime(header); err == nil && time.Until(date) > 0 {
+		return time.Until(date), nil
+	}
+
+	return 0, nil
+}
+
+// discardLogger silences the resty logging of every failed attempt, failures are reported by the caller
+// and retries are summarized by RetriedRequests
+type discardLogger struct{}
+
+func (discardLogger) Errorf(string, ...interface{}) {}
+
+func (discardLogger) Warnf(string, ...interface{}) {}
+
+func (discardLogger) Debugf(string, ...interface{}) {}
@@ -0,0 +1,195 @@
+package oceancd
+
+import (
+	"context"
+	"github.com/go-resty/resty/v2"
+	"github.com/google/go-cmp/cmp"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testMaxRetries = 2
+
+func newTestClient(url string) *Client {
+	return NewClient(ClientOptions{BaseUrl: url, Token: "token", MaxRetries: testMaxRetries, RetryWaitTime: time.Millisecond})
+}
+
+func TestIsRetryable(t *testing.T) {
+	cases := map[string]struct {
+		status           int
+		dropConnection   bool
+		method           string
+		expectedAttempts int32
+	}{
+		"get server error":                {status: http.StatusServiceUnavailable, method: http.MethodGet, expectedAttempts: testMaxRetries + 1},
+		"get rate limited":                {status: http.StatusTooManyRequests, method: http.MethodGet, expectedAttempts: testMaxRetries + 1},
+		"get not found":                   {status: http.StatusNotFound, method: http.MethodGet, expectedAttempts: 1},
+		"get dropped connection":          {dropConnection: true, method: http.MethodGet, expectedAttempts: testMaxRetries + 1},
+		"rollout action server error":     {status: http.StatusInternalServerError, method: http.MethodPut, expectedAttempts: 1},
+		"rollout action rate limited":     {status: http.StatusTooManyRequests, method: http.MethodPut, expectedAttempts: 1},
+		"rollout action gateway timeout":  {status: http.StatusGatewayTimeout, method: http.MethodPut, expectedAttempts: 1},
+		"rollout action dropped response": {dropConnection: true, method: http.MethodPut, expectedAttempts: 1},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&attempts, 1)
+
+				if tc.dropConnection {
+					conn, _, err := w.(http.Hijacker).Hijack()
+					if err == nil {
+						conn.Close()
+					}
+
+					return
+				}
+
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			client := newTestClient(server.URL)
+			if tc.method == http.MethodPut {
+				_ = client.SendRolloutAction(context.Background(), "rol-1", map[string]string{"action": "promote"})
+			} else {
+				_, _ = client.ListEntities(context.Background(), "strategy")
+			}
+
+			if actual := atomic.LoadInt32(&attempts); actual != tc.expectedAttempts {
+				t.Errorf("expected %d attempts, got %d", tc.expectedAttempts, actual)
+			}
+		})
+	}
+}
+
+func TestIsRetryableRefusedConnection(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	client := newTestClient(url)
+	if _, err := client.ListEntities(context.Background(), "strategy"); err == nil {
+		t.Fatal("expected the list to fail")
+	}
+
+	if err := client.SendRolloutAction(context.Background(), "rol-1", map[string]string{"action": "promote"}); err == nil {
+		t.Fatal("expected the action to fail")
+	}
+
+	retried := client.RetriedRequests()
+	if len(retried) != 1 || retried[0].Method != http.MethodGet || retried[0].FailedAttempts != testMaxRetries+1 {
+		t.Errorf("expected only the refused list to be retried %d times, got %+v", testMaxRetries, retried)
+	}
+}
+
+func TestIsRetryableCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		cancel()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	_, _ = newTestClient(server.URL).ListEntities(ctx, "strategy")
+
+	if actual := atomic.LoadInt32(&attempts); actual != 1 {
+		t.Errorf("expected a single attempt once the context is canceled, got %d", actual)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	cases := map[string]struct {
+		header   string
+		expected time.Duration
+		minimum  time.Duration
+	}{
+		"no header":     {header: "", expected: 0},
+		"seconds":       {header: "2", expected: 2 * time.Second},
+		"zero seconds":  {header: "0", expected: 0},
+		"negative":      {header: "-3", expected: 0},
+		"future date":   {header: time.Now().Add(time.Minute).UTC().Format(http.TimeFormat), minimum: 30 * time.Second},
+		"past date":     {header: time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), expected: 0},
+		"invalid value": {header: "soon", expected: 0},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			response := &resty.Response{RawResponse: &http.Response{Header: http.Header{}}}
+			if tc.header != "" {
+				response.RawResponse.Header.Set("Retry-After", tc.header)
+			}
+
+			actual, err := retryAfter(nil, response)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.minimum > 0 {
+				if actual < tc.minimum || actual > time.Minute {
+					t.Errorf("expected a wait between %s and 1m, got %s", tc.minimum, actual)
+				}
+
+				return
+			}
+
+			if actual != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRetriedRequests(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"response": {"items": []}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOptions{BaseUrl: server.URL, MaxRetries: 3, RetryWaitTime: time.Millisecond})
+	if _, err := client.ListEntities(context.Background(), "strategy"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.ListEntities(context.Background(), "strategy"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []RetriedRequest{
+		{Method: http.MethodGet, Url: server.URL + "/ocean/cd/strategy", FailedAttempts: 2, LastReason: "502 Bad Gateway"},
+	}
+
+	if diff := cmp.Diff(expected, client.RetriedRequests()); diff != "" {
+		t.Errorf("retried requests mismatch (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestRetriesDisabled(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOptions{BaseUrl: server.URL})
+	_, _ = client.ListEntities(context.Background(), "strategy")
+
+	if actual := atomic.LoadInt32(&attempts); actual != 1 || len(client.RetriedRequests()) != 0 {
+		t.Errorf("expected a single attempt without retries, got %d attempts and %v", actual, client.RetriedRequests())
+	}
+}
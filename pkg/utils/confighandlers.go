@@ -5,10 +5,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"gopkg.in/yaml.v3"
 	"io"
+	"io/fs"
 	"io/ioutil"
+	"os"
 	fp "path/filepath"
+	"strings"
 )
 
 type Config struct {
@@ -19,6 +23,16 @@ type Config struct {
 type Options struct {
 	SingleResource bool
 	PathToConfig   string
+	// Content holds the resources read from the standard input, instead of the ones of PathToConfig
+	Content []byte
+}
+
+func (o Options) read() ([]byte, error) {
+	if o.Content != nil {
+		return o.Content, nil
+	}
+
+	return ioutil.ReadFile(o.PathToConfig)
 }
 
 type commandHandler func(ctx context.Context, resource map[string]interface{}) error
@@ -28,6 +42,16 @@ type ConfigHandler interface {
 }
 
 func NewConfigHandler(options Options) (ConfigHandler, error) {
+	if options.PathToConfig == StdinPath {
+		content, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+
+		options.Content = content
+		return newContentConfigHandler(options), nil
+	}
+
 	if options.PathToConfig != "" {
 		fileExtension := fp.Ext(options.PathToConfig)[1:]
 
@@ -44,6 +68,126 @@ func NewConfigHandler(options Options) (ConfigHandler, error) {
 	return &DefaultConfigHandler{options}, nil
 }
 
+// StdinPath reads the resources from the standard input, in either json or yaml format
+const StdinPath = "-"
+
+// ConfigFiles resolves the given files, directories and globs to the files holding the resources, in order.
+// A directory gives its json and yaml files sorted by name, including the ones of its sub directories when recursive.
+func ConfigFiles(paths []string, recursive bool) ([]string, error) {
+	var retVal []string
+	listed := map[string]bool{}
+
+	add := func(files ...string) {
+		for _, file := range files {
+			if listed[file] == false {
+				retVal = append(retVal, file)
+				listed[file] = true
+			}
+		}
+	}
+
+	for _, path := range paths {
+		if path == StdinPath {
+			if listed[StdinPath] {
+				return nil, errors.New("error: The standard input can only be read once")
+			}
+
+			add(StdinPath)
+			continue
+		}
+
+		matches := []string{path}
+		if isGlob(path) {
+			globMatches, err := fp.Glob(path)
+			if err != nil {
+				return nil, fmt.Errorf("error: Invalid pattern %s - %w", path, err)
+			}
+
+			if len(globMatches) == 0 {
+				return nil, fmt.Errorf("error: No files match %s", path)
+			}
+
+			matches = globMatches
+		}
+
+		for _, match := range matches {
+			files, err := configFilesOf(match, recursive, isGlob(path))
+			if err != nil {
+				return nil, err
+			}
+
+			add(files...)
+		}
+	}
+
+	return retVal, nil
+}
+
+// configFilesOf lists the config files of a path, the files of a glob with another extension are skipped
+func configFilesOf(path string, recursive bool, isGlobMatch bool) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() == false {
+		if supportedFileTypes[fp.Ext(path)] == false {
+			if isGlobMatch {
+				return nil, nil
+			}
+
+			return nil, IsFileTypeSupported(fp.Ext(path))
+		}
+
+		return []string{path}, nil
+	}
+
+	var retVal []string
+	err = fp.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if file != path && recursive == false {
+				return fp.SkipDir
+			}
+
+			return nil
+		}
+
+		if supportedFileTypes[fp.Ext(file)] {
+			retVal = append(retVal, file)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(retVal) == 0 {
+		return nil, fmt.Errorf("error: No json or yaml files found in %s", path)
+	}
+
+	return retVal, nil
+}
+
+func isGlob(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// newContentConfigHandler picks the handler from the format of the content, since it has no file extension.
+// json documents start with an object or an array, anything else is taken as yaml.
+func newContentConfigHandler(options Options) ConfigHandler {
+	trimmed := bytes.TrimSpace(options.Content)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return &JsonConfigHandler{options}
+	}
+
+	return &YamlConfigHandler{options}
+}
+
 type DefaultConfigHandler struct {
 	Options
 }
@@ -88,7 +232,7 @@ func (h *JsonConfigHandler) Handle(ctx context.Context, commandHandler commandHa
 func (h *JsonConfigHandler) ToMap() (map[string]interface{}, error) {
 	var retVal map[string]interface{}
 
-	bytesContent, err := ioutil.ReadFile(h.Options.PathToConfig)
+	bytesContent, err := h.Options.read()
 	if err != nil {
 		return nil, err
 	}
@@ -104,7 +248,7 @@ func (h *JsonConfigHandler) ToMap() (map[string]interface{}, error) {
 func (h *JsonConfigHandler) ToArrayOfMaps() ([]map[string]interface{}, error) {
 	var retVal []map[string]interface{}
 
-	bytesContent, err := ioutil.ReadFile(h.Options.PathToConfig)
+	bytesContent, err := h.Options.read()
 	if err != nil {
 		return nil, err
 	}
@@ -151,7 +295,7 @@ func (h *YamlConfigHandler) Handle(ctx context.Context, commandHandler commandHa
 func (h *YamlConfigHandler) ToMap() ([]map[string]interface{}, error) {
 	retVal := make([]map[string]interface{}, 0)
 
-	fileBytes, err := ioutil.ReadFile(h.Options.PathToConfig)
+	fileBytes, err := h.Options.read()
 	if err != nil {
 		return nil, err
 	}
@@ -177,7 +321,7 @@ func (h *YamlConfigHandler) ToMap() ([]map[string]interface{}, error) {
 func (h *YamlConfigHandler) ToArrayOfMaps() ([]map[string]interface{}, error) {
 	var retVal []map[string]interface{}
 
-	bytesContent, err := ioutil.ReadFile(h.Options.PathToConfig)
+	bytesContent, err := h.Options.read()
 	if err != nil {
 		return nil, err
 	}
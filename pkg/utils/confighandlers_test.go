@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"context"
+	"github.com/google/go-cmp/cmp"
+	"os"
+	fp "path/filepath"
+	"testing"
+)
+
+func TestConfigFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, file := range []string{"b.yaml", "a.json", "notes.txt", "nested/c.yml", "nested/deeper/d.json", "other/e.yaml"} {
+		path := fp.Join(dir, file)
+		if err := os.MkdirAll(fp.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := map[string]struct {
+		paths     []string
+		recursive bool
+		expected  []string
+		expectErr bool
+	}{
+		"file":                       {paths: []string{"b.yaml"}, expected: []string{"b.yaml"}},
+		"directory":                  {paths: []string{"."}, expected: []string{"a.json", "b.yaml"}},
+		"recursive directory":        {paths: []string{"."}, recursive: true, expected: []string{"a.json", "b.yaml", "nested/c.yml", "nested/deeper/d.json", "other/e.yaml"}},
+		"glob skips other types":     {paths: []string{"*"}, expected: []string{"a.json", "b.yaml", "nested/c.yml", "other/e.yaml"}},
+		"repeated files listed once": {paths: []string{"b.yaml", "*.yaml", "-"}, expected: []string{"b.yaml", "-"}},
+		"unsupported file":           {paths: []string{"notes.txt"}, expectErr: true},
+		"glob without matches":       {paths: []string{"*.toml"}, expectErr: true},
+		"stdin read twice":           {paths: []string{"-", "-"}, expectErr: true},
+		"missing file":               {paths: []string{"missing.json"}, expectErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			paths := make([]string, len(tc.paths))
+			for i, path := range tc.paths {
+				paths[i] = path
+				if path != StdinPath {
+					paths[i] = fp.Join(dir, path)
+				}
+			}
+
+			actual, err := ConfigFiles(paths, tc.recursive)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", actual)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i, file := range actual {
+				if file != StdinPath {
+					actual[i], _ = fp.Rel(dir, file)
+				}
+			}
+
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("files mismatch (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestContentFormatSniffing(t *testing.T) {
+	cases := map[string]struct {
+		content  string
+		expected []string
+	}{
+		"json object": {content: `{"kind": "Strategy", "name": "canary"}`, expected: []string{"canary"}},
+		"json array":  {content: "\n  [{\"kind\": \"Strategy\", \"name\": \"canary\"}, {\"kind\": \"Strategy\", \"name\": \"linear\"}]", expected: []string{"canary", "linear"}},
+		"yaml":        {content: "kind: Strategy\nname: canary\n---\nkind: Strategy\nname: linear\n", expected: []string{"canary", "linear"}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var actual []string
+			handler := newContentConfigHandler(Options{PathToConfig: StdinPath, Content: []byte(tc.content)})
+			err := handler.Handle(context.Background(), func(_ context.Context, resource map[string]interface{}) error {
+				actual = append(actual, resource["name"].(string))
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("resources mismatch (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
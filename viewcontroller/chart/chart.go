@@ -0,0 +1,205 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	chartPoint     = "●"
+	chartThreshold = "┄"
+	chartAxis      = "┤"
+	labelWidth     = 10
+)
+
+var (
+	sparkTicks       = []rune("▁▂▃▄▅▆▇█")
+	thresholdPattern = regexp.MustCompile(`(>=|<=|==|!=|>|<)\s*(-?\d+(?:\.\d+)?(?:[eE][-+]?\d+)?)`)
+	valuePattern     = regexp.MustCompile(`-?\d+(?:\.\d+)?(?:[eE][-+]?\d+)?`)
+)
+
+// Threshold is the value a failure condition compares the measured result with, e.g. "result[0] > 0.05"
+type Threshold struct {
+	Operator string
+	Value    float64
+}
+
+// ParseThreshold extracts the first comparison of a failure condition, conditions without one have no threshold
+func ParseThreshold(condition string) (*Threshold, bool) {
+	match := thresholdPattern.FindStringSubmatch(condition)
+	if match == nil {
+		return nil, false
+	}
+
+	value, err := strconv.ParseFloat(match[2], 64)
+	if err != nil {
+		return nil, false
+	}
+
+	return &Threshold{Operator: match[1], Value: value}, true
+}
+
+func (t *Threshold) String() string {
+	return fmt.Sprintf("%s %s", t.Operator, FormatValue(t.Value))
+}
+
+// IsBreachedBy reports whether the value meets the failure condition
+func (t *Threshold) IsBreachedBy(value float64) bool {
+	switch t.Operator {
+	case ">":
+		return value > t.Value
+	case ">=":
+		return value >= t.Value
+	case "<":
+		return value < t.Value
+	case "<=":
+		return value <= t.Value
+	case "==":
+		return value == t.Value
+	case "!=":
+		return value != t.Value
+	default:
+		return false
+	}
+}
+
+// ParseValue reads the first number of a measured value, e.g. "0.07" or "[0.07]"
+func ParseValue(raw string) (float64, bool) {
+	match := valuePattern.FindString(strings.TrimSpace(raw))
+	if match == "" {
+		return 0, false
+	}
+
+	value, err := strconv.ParseFloat(match, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, false
+	}
+
+	return value, true
+}
+
+func FormatValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', 4, 64)
+}
+
+// Series holds the numeric measurements of a single metric in the order they were taken
+type Series struct {
+	Values    []float64
+	Failed    []bool
+	Threshold *Threshold
+}
+
+func (s *Series) Add(value float64, failed bool) {
+	s.Values = append(s.Values, value)
+	s.Failed = append(s.Failed, failed)
+}
+
+// Bounds returns the lowest and highest values, the threshold included so it always fits the chart
+func (s *Series) Bounds() (float64, float64) {
+	min, max := math.Inf(1), math.Inf(-1)
+	values := s.Values
+	if s.Threshold != nil {
+		values = append([]float64{s.Threshold.Value}, values...)
+	}
+
+	for _, value := range values {
+		min = math.Min(min, value)
+		max = math.Max(max, value)
+	}
+
+	return min, max
+}
+
+// Sparkline renders every value as a block proportional to its height, failed values are passed to highlight
+func Sparkline(series Series, highlight func(string) string) string {
+	if len(series.Values) == 0 {
+		return ""
+	}
+
+	min, max := series.Bounds()
+	builder := strings.Builder{}
+
+	for i, value := range series.Values {
+		tick := string(sparkTicks[scale(value, min, max, len(sparkTicks))])
+		if series.Failed[i] {
+			tick = highlight(tick)
+		}
+
+		builder.WriteString(tick)
+	}
+
+	return builder.String()
+}
+
+// LineChart renders the values over the given number of rows with the threshold drawn as a dashed line.
+// Failed values are passed to highlight and the threshold line to thresholdStyle.
+func LineChart(series Series, height int, highlight func(string) string, thresholdStyle func(string) string) []string {
+	if len(series.Values) == 0 {
+		return nil
+	}
+
+	if height < 2 {
+		height = 2
+	}
+
+	min, max := series.Bounds()
+	thresholdRow := -1
+	if series.Threshold != nil {
+		thresholdRow = scale(series.Threshold.Value, min, max, height)
+	}
+
+	rows := make([]string, 0, height)
+	for row := height - 1; row >= 0; row-- {
+		label := ""
+		switch row {
+		case height - 1:
+			label = FormatValue(max)
+		case 0:
+			label = FormatValue(min)
+		case thresholdRow:
+			label = FormatValue(series.Threshold.Value)
+		}
+
+		builder := strings.Builder{}
+		builder.WriteString(fmt.Sprintf("%*s %s", labelWidth, label, chartAxis))
+
+		for i, value := range series.Values {
+			switch {
+			case scale(value, min, max, height) == row && series.Failed[i]:
+				builder.WriteString(highlight(chartPoint))
+			case scale(value, min, max, height) == row:
+				builder.WriteString(chartPoint)
+			case row == thresholdRow:
+				builder.WriteString(thresholdStyle(chartThreshold))
+			default:
+				builder.WriteString(" ")
+			}
+			builder.WriteString(" ")
+		}
+
+		rows = append(rows, strings.TrimRight(builder.String(), " "))
+	}
+
+	return rows
+}
+
+// scale maps the value within the bounds to one of the levels, 0 being the lowest
+func scale(value float64, min float64, max float64, levels int) int {
+	if max <= min {
+		return levels / 2
+	}
+
+	level := int(math.Round((value - min) / (max - min) * float64(levels-1)))
+	if level < 0 {
+		return 0
+	}
+
+	if level > levels-1 {
+		return levels - 1
+	}
+
+	return level
+}
@@ -0,0 +1,198 @@
+package chart
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestParseThreshold(t *testing.T) {
+	cases := map[string]struct {
+		condition string
+		expected  *Threshold
+	}{
+		"greater":            {condition: "result[0] > 0.05", expected: &Threshold{Operator: ">", Value: 0.05}},
+		"greater or equal":   {condition: "result >= 10", expected: &Threshold{Operator: ">=", Value: 10}},
+		"less without space": {condition: "result[0]<-3", expected: &Threshold{Operator: "<", Value: -3}},
+		"less or equal":      {condition: "result <= 1e3", expected: &Threshold{Operator: "<=", Value: 1000}},
+		"equal":              {condition: "result == 0", expected: &Threshold{Operator: "==", Value: 0}},
+		"not equal":          {condition: "result != 1.5", expected: &Threshold{Operator: "!=", Value: 1.5}},
+		"first comparison":   {condition: "result[0] > 0.1 || result[1] < 5", expected: &Threshold{Operator: ">", Value: 0.1}},
+		"no comparison":      {condition: "isNaN(result)"},
+		"empty":              {condition: ""},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual, ok := ParseThreshold(tc.condition)
+			if ok != (tc.expected != nil) {
+				t.Fatalf("expected a threshold %v, got %v", tc.expected != nil, ok)
+			}
+
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("threshold mismatch (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsBreachedBy(t *testing.T) {
+	cases := []struct {
+		operator string
+		value    float64
+		expected bool
+	}{
+		{operator: ">", value: 0.06, expected: true},
+		{operator: ">", value: 0.05, expected: false},
+		{operator: ">=", value: 0.05, expected: true},
+		{operator: ">=", value: 0.04, expected: false},
+		{operator: "<", value: 0.04, expected: true},
+		{operator: "<", value: 0.05, expected: false},
+		{operator: "<=", value: 0.05, expected: true},
+		{operator: "<=", value: 0.06, expected: false},
+		{operator: "==", value: 0.05, expected: true},
+		{operator: "==", value: 0.06, expected: false},
+		{operator: "!=", value: 0.06, expected: true},
+		{operator: "!=", value: 0.05, expected: false},
+		{operator: "~", value: 0.05, expected: false},
+	}
+
+	for _, tc := range cases {
+		threshold := Threshold{Operator: tc.operator, Value: 0.05}
+		if actual := threshold.IsBreachedBy(tc.value); actual != tc.expected {
+			t.Errorf("%v %s 0.05: expected %v, got %v", tc.value, tc.operator, tc.expected, actual)
+		}
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	cases := map[string]struct {
+		raw      string
+		expected float64
+		ok       bool
+	}{
+		"number":          {raw: "0.07", expected: 0.07, ok: true},
+		"padded":          {raw: "  42 ", expected: 42, ok: true},
+		"list":            {raw: "[0.07]", expected: 0.07, ok: true},
+		"first of a list": {raw: "[3, 4]", expected: 3, ok: true},
+		"negative":        {raw: "-1.5", expected: -1.5, ok: true},
+		"exponent":        {raw: "2.5e-3", expected: 0.0025, ok: true},
+		"not a number":    {raw: "NaN"},
+		"empty list":      {raw: "[]"},
+		"empty":           {raw: ""},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual, ok := ParseValue(tc.raw)
+			if ok != tc.ok || actual != tc.expected {
+				t.Errorf("expected %v %v, got %v %v", tc.expected, tc.ok, actual, ok)
+			}
+		})
+	}
+}
+
+func TestScale(t *testing.T) {
+	cases := map[string]struct {
+		value    float64
+		min      float64
+		max      float64
+		levels   int
+		expected int
+	}{
+		"lowest":          {value: 0, min: 0, max: 10, levels: 8, expected: 0},
+		"highest":         {value: 10, min: 0, max: 10, levels: 8, expected: 7},
+		"middle":          {value: 5, min: 0, max: 10, levels: 5, expected: 2},
+		"rounded":         {value: 1.5, min: 0, max: 3, levels: 4, expected: 2},
+		"below the range": {value: -5, min: 0, max: 10, levels: 8, expected: 0},
+		"above the range": {value: 20, min: 0, max: 10, levels: 8, expected: 7},
+		"min equals max":  {value: 3, min: 3, max: 3, levels: 8, expected: 4},
+		"min above max":   {value: 3, min: 4, max: 3, levels: 5, expected: 2},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if actual := scale(tc.value, tc.min, tc.max, tc.levels); actual != tc.expected {
+				t.Errorf("expected level %d, got %d", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestBounds(t *testing.T) {
+	series := Series{Values: []float64{2, 4}, Threshold: &Threshold{Operator: ">", Value: 10}}
+	if min, max := series.Bounds(); min != 2 || max != 10 {
+		t.Errorf("expected the threshold within the bounds 2..10, got %v..%v", min, max)
+	}
+}
+
+func highlight(value string) string {
+	return "[" + value + "]"
+}
+
+func TestSparkline(t *testing.T) {
+	series := Series{}
+	series.Add(0, false)
+	series.Add(7, true)
+	series.Add(3.5, false)
+
+	if actual := Sparkline(series, highlight); actual != "▁[█]▅" {
+		t.Errorf("unexpected sparkline %q", actual)
+	}
+
+	if actual := Sparkline(Series{}, highlight); actual != "" {
+		t.Errorf("expected an empty sparkline without values, got %q", actual)
+	}
+}
+
+func TestLineChart(t *testing.T) {
+	cases := map[string]struct {
+		series   Series
+		height   int
+		expected []string
+	}{
+		"threshold row": {
+			series: Series{
+				Values:    []float64{0, 1, 2, 3},
+				Failed:    []bool{false, false, true, true},
+				Threshold: &Threshold{Operator: ">", Value: 1.5},
+			},
+			height: 4,
+			expected: []string{
+				"         3 ┤      [●]",
+				"       1.5 ┤<┄> <┄> [●] <┄>",
+				"           ┤  ●",
+				"         0 ┤●",
+			},
+		},
+		"min equals max": {
+			series: Series{Values: []float64{5, 5}, Failed: []bool{false, false}},
+			height: 3,
+			expected: []string{
+				"         5 ┤",
+				"           ┤● ●",
+				"         5 ┤",
+			},
+		},
+		"minimal height": {
+			series: Series{Values: []float64{1, 2}, Failed: []bool{false, false}},
+			height: 1,
+			expected: []string{
+				"         2 ┤  ●",
+				"         1 ┤●",
+			},
+		},
+		"no values": {
+			series: Series{Threshold: &Threshold{Operator: ">", Value: 1}},
+			height: 4,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual := LineChart(tc.series, tc.height, highlight, func(value string) string { return "<" + value + ">" })
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("chart mismatch (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package converter
 import (
 	"bytes"
 	"fmt"
+	"spot-oceancd-cli/pkg/oceancd/model"
 	"spot-oceancd-cli/pkg/oceancd/model/phase"
 	"spot-oceancd-cli/pkg/oceancd/model/rollout"
 	"spot-oceancd-cli/pkg/oceancd/model/verification"
@@ -11,11 +12,9 @@ import (
 	"unicode"
 )
 
-const stubCell = "--"
-
 func Weight(phase phase.Phase) string {
 	if phase.TrafficPercentage < 1 {
-		return stubCell
+		return model.StubCell
 	} else {
 		return strconv.Itoa(phase.TrafficPercentage)
 	}
@@ -27,7 +26,7 @@ func PhaseIndex(index int) string {
 
 func PhaseName(phase phase.Phase) string {
 	if phase.Name == "" {
-		return stubCell
+		return model.StubCell
 	}
 	return phase.Name
 }
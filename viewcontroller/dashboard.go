@@ -0,0 +1,675 @@
+package viewcontroller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/fatih/color"
+	"golang.org/x/term"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"spot-oceancd-cli/pkg/oceancd"
+	"spot-oceancd-cli/pkg/oceancd/builders"
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+	"spot-oceancd-cli/pkg/oceancd/repositories"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	dashboardMinListWidth   = 28
+	dashboardMaxListWidth   = 56
+	dashboardMinDetailsRows = 6
+	dashboardMinWidth       = 60
+	dashboardMinHeight      = 12
+	dashboardResizeInterval = 250 * time.Millisecond
+	dashboardSeparator      = "│"
+	dashboardSelected       = "▶"
+	dashboardEllipsis       = "…"
+	dashboardHelp           = "↑/↓ select  tab focus  pgup/pgdn scroll  p promote  P promote full  s pause  a abort  r retry  q quit"
+)
+
+// terminal control sequences
+const (
+	enterAltScreen = "\033[?1049h\033[?25l"
+	exitAltScreen  = "\033[?25h\033[?1049l"
+	cursorHome     = "\033[H"
+	clearLine      = "\033[K"
+	clearBelow     = "\033[J"
+	resetStyle     = "\033[0m"
+)
+
+// keys
+const (
+	keyUp       = "up"
+	keyDown     = "down"
+	keyPageUp   = "pgup"
+	keyPageDown = "pgdown"
+	keyTab      = "tab"
+	keyEscape   = "esc"
+	keyEnter    = "enter"
+	keyCtrlC    = "ctrl+c"
+)
+
+var (
+	ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[a-zA-Z]`)
+	escapeKeys  = map[string]string{
+		"\x1b[A":  keyUp,
+		"\x1b[B":  keyDown,
+		"\x1bOA":  keyUp,
+		"\x1bOB":  keyDown,
+		"\x1b[5~": keyPageUp,
+		"\x1b[6~": keyPageDown,
+	}
+)
+
+type dashboardFocus int
+
+const (
+	focusRollouts dashboardFocus = iota
+	focusDetails
+)
+
+// dashboardAction is a rollout action triggered by a key, sent once the user confirms it
+type dashboardAction struct {
+	action   string
+	pastForm string
+}
+
+var dashboardActions = map[string]dashboardAction{
+	"p": {action: oceancd.PromoteAction, pastForm: "promoted"},
+	"P": {action: oceancd.PromoteFullAction, pastForm: "fully promoted"},
+	"s": {action: oceancd.PauseAction, pastForm: "paused"},
+	"a": {action: oceancd.AbortAction, pastForm: "rolled back"},
+	"r": {action: oceancd.RetryAction, pastForm: "retried"},
+}
+
+// DashboardViewController is a full-screen interactive view of the rollouts.
+// The rollouts are listed on the left and the selected one is detailed on the right.
+type DashboardViewController struct {
+	*viewController
+	repository       repositories.RolloutRepository
+	filter           oceancd.RolloutFilter
+	includeCompleted bool
+	interval         time.Duration
+	mutex            sync.Mutex
+	rollouts         []rollout.Rollout
+	selectedId       string
+	detailedRollout  *rollout.DetailedRollout
+	detailsOffset    int
+	detailsRows      int
+	focus            dashboardFocus
+	confirmation     *dashboardAction
+	message          string
+	width            int
+	height           int
+	changes          chan struct{}
+	refreshNow       chan struct{}
+}
+
+func NewDashboardViewController(repository repositories.RolloutRepository, filter oceancd.RolloutFilter, noColor bool) *DashboardViewController {
+	vc := newViewController(noColor)
+
+	return &DashboardViewController{
+		viewController: vc,
+		repository:     repository,
+		filter:         filter,
+		interval:       DefaultWatchInterval,
+		changes:        make(chan struct{}, 1),
+		refreshNow:     make(chan struct{}, 1),
+	}
+}
+
+// WithCompleted lists finished, failed, aborted and canceled rollouts along with the active ones
+func (c *DashboardViewController) WithCompleted(includeCompleted bool) *DashboardViewController {
+	c.includeCompleted = includeCompleted
+	return c
+}
+
+// WithInterval sets how often the rollouts are refreshed
+func (c *DashboardViewController) WithInterval(interval time.Duration) *DashboardViewController {
+	if interval > 0 {
+		c.interval = interval
+	}
+
+	return c
+}
+
+// Run takes over the terminal until the user quits or the context is done
+func (c *DashboardViewController) Run(ctx context.Context) error {
+	inputFd, outputFd := int(os.Stdin.Fd()), int(os.Stdout.Fd())
+	if term.IsTerminal(inputFd) == false || term.IsTerminal(outputFd) == false {
+		return errors.New("error: The dashboard requires an interactive terminal")
+	}
+
+	state, err := term.MakeRaw(inputFd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(inputFd, state)
+
+	fmt.Fprint(c.writer, enterAltScreen)
+	defer fmt.Fprint(c.writer, exitAltScreen)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	keys := make(chan string)
+	go c.readKeys(ctx, keys)
+	go c.poll(ctx)
+
+	// resizing is detected by polling the terminal size, which works the same on every platform
+	resizeTicker := time.NewTicker(dashboardResizeInterval)
+	defer resizeTicker.Stop()
+
+	c.draw()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case key := <-keys:
+			if c.handleKey(ctx, key) == false {
+				return nil
+			}
+			c.draw()
+		case <-c.changes:
+			c.draw()
+		case <-resizeTicker.C:
+			if c.isResized() {
+				c.draw()
+			}
+		}
+	}
+}
+
+func (c *DashboardViewController) readKeys(ctx context.Context, keys chan<- string) {
+	buffer := make([]byte, 64)
+
+	for {
+		n, err := os.Stdin.Read(buffer)
+		if err != nil {
+			return
+		}
+
+		for _, key := range parseKeys(buffer[:n]) {
+			select {
+			case keys <- key:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// parseKeys splits the raw terminal input into named keys, printable characters are kept as they are
+func parseKeys(input []byte) []string {
+	var retVal []string
+
+	for i := 0; i < len(input); {
+		if input[i] == '\x1b' {
+			matched := false
+			for sequence, key := range escapeKeys {
+				if bytes.HasPrefix(input[i:], []byte(sequence)) {
+					retVal = append(retVal, key)
+					i += len(sequence)
+					matched = true
+					break
+				}
+			}
+
+			if matched == false {
+				retVal = append(retVal, keyEscape)
+				i++
+			}
+
+			continue
+		}
+
+		switch input[i] {
+		case 3:
+			retVal = append(retVal, keyCtrlC)
+		case '\t':
+			retVal = append(retVal, keyTab)
+		case '\r', '\n':
+			retVal = append(retVal, keyEnter)
+		default:
+			retVal = append(retVal, string(input[i]))
+		}
+		i++
+	}
+
+	return retVal
+}
+
+// poll refreshes the rollouts, slowing down while they do not change. Selecting a rollout or sending
+// a rollout action refreshes them right away.
+func (c *DashboardViewController) poll(ctx context.Context) {
+	c.refresh(ctx)
+
+	pollAdaptively(ctx, c.interval, c.refreshNow, func() (bool, bool) {
+		return c.refresh(ctx), false
+	})
+}
+
+// refresh fetches the rollouts and the selected rollout details, and reports whether any of them changed
+func (c *DashboardViewController) refresh(ctx context.Context) bool {
+	defer c.notify()
+
+	rollouts, err := c.repository.ListRollouts(ctx, c.filter)
+	if err != nil {
+		if ctx.Err() == nil {
+			c.setMessage(fmt.Sprintf("Failed to list rollouts - %s", err.Error()))
+		}
+		return false
+	}
+
+	visibleRollouts := make([]rollout.Rollout, 0, len(rollouts))
+	for _, rolloutItem := range rollouts {
+		if c.includeCompleted || rolloutItem.Status.IsCompleted() == false {
+			visibleRollouts = append(visibleRollouts, rolloutItem)
+		}
+	}
+
+	sort.SliceStable(visibleRollouts, func(i, j int) bool {
+		return visibleRollouts[i].StartTime > visibleRollouts[j].StartTime
+	})
+
+	c.mutex.Lock()
+	changed := reflect.DeepEqual(c.rollouts, visibleRollouts) == false
+	c.rollouts = visibleRollouts
+	if c.indexOf(c.selectedId) < 0 {
+		c.selectedId = ""
+		c.detailedRollout = nil
+		if len(visibleRollouts) > 0 {
+			c.selectedId = visibleRollouts[0].Id
+		}
+	}
+	selectedId := c.selectedId
+	var strategy rollout.Strategy
+	if c.detailedRollout != nil && c.detailedRollout.Id == selectedId {
+		strategy = c.detailedRollout.Definition.Strategy
+	}
+	c.mutex.Unlock()
+
+	if selectedId == "" {
+		return changed
+	}
+
+	// the strategy never changes during a rollout, so it is only fetched once
+	detailedRolloutBuilder := builders.NewDetailedRolloutBuilder(c.repository)
+	if strategy == nil {
+		detailedRolloutBuilder.WithStrategy()
+	}
+
+	detailedRollout, err := detailedRolloutBuilder.Build(ctx, selectedId)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err != nil {
+		if ctx.Err() == nil {
+			// the status line is a single line, while a failed build lists every failed request on its own line
+			c.message = fmt.Sprintf("Failed to get the rollout %s - %s", selectedId, strings.ReplaceAll(err.Error(), "\n", "; "))
+		}
+		return changed
+	}
+
+	if strategy != nil {
+		detailedRollout.Definition.Strategy = strategy
+	}
+
+	if c.selectedId == selectedId {
+		changed = changed || reflect.DeepEqual(c.detailedRollout, detailedRollout) == false
+		c.detailedRollout = detailedRollout
+	}
+
+	return changed
+}
+
+func (c *DashboardViewController) handleKey(ctx context.Context, key string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.confirmation != nil {
+		action := *c.confirmation
+		c.confirmation = nil
+
+		if key == "y" || key == "Y" {
+			c.message = fmt.Sprintf("Sending %s to the rollout %s", action.action, c.selectedId)
+			go c.sendAction(ctx, action, c.selectedId)
+		} else {
+			c.message = fmt.Sprintf("Canceled %s of the rollout %s", action.action, c.selectedId)
+		}
+
+		return true
+	}
+
+	switch key {
+	case "q", keyCtrlC:
+		return false
+	case keyTab:
+		c.focus = (c.focus + 1) % 2
+	case keyUp, "k":
+		if c.focus == focusRollouts {
+			c.moveSelection(-1)
+		} else {
+			c.detailsOffset--
+		}
+	case keyDown, "j":
+		if c.focus == focusRollouts {
+			c.moveSelection(1)
+		} else {
+			c.detailsOffset++
+		}
+	case keyPageUp:
+		c.detailsOffset -= c.detailsRows
+	case keyPageDown:
+		c.detailsOffset += c.detailsRows
+	case keyEscape:
+		c.message = ""
+	default:
+		if action, ok := dashboardActions[key]; ok && c.selectedId != "" {
+			c.confirmation = &action
+		}
+	}
+
+	return true
+}
+
+func (c *DashboardViewController) moveSelection(step int) {
+	index := c.indexOf(c.selectedId) + step
+	if index < 0 || index >= len(c.rollouts) {
+		return
+	}
+
+	c.selectedId = c.rollouts[index].Id
+	c.detailedRollout = nil
+	c.detailsOffset = 0
+
+	select {
+	case c.refreshNow <- struct{}{}:
+	default:
+	}
+}
+
+func (c *DashboardViewController) sendAction(ctx context.Context, action dashboardAction, rolloutId string) {
+	err := c.repository.SendRolloutAction(ctx, rolloutId, map[string]string{"action": action.action})
+	if err != nil {
+		c.setMessage(fmt.Sprintf("Failed to %s the rollout %s: %s", action.action, rolloutId, err.Error()))
+	} else {
+		c.setMessage(fmt.Sprintf("Successfully %s resource %s", action.pastForm, rolloutId))
+	}
+
+	select {
+	case c.refreshNow <- struct{}{}:
+	default:
+	}
+
+	c.notify()
+}
+
+func (c *DashboardViewController) indexOf(rolloutId string) int {
+	for i, rolloutItem := range c.rollouts {
+		if rolloutItem.Id == rolloutId {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (c *DashboardViewController) setMessage(message string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.message = message
+}
+
+// notify asks for a redraw without blocking, pending redraws are merged into one
+func (c *DashboardViewController) notify() {
+	select {
+	case c.changes <- struct{}{}:
+	default:
+	}
+}
+
+func (c *DashboardViewController) terminalSize() (int, int) {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+
+	return width, height
+}
+
+func (c *DashboardViewController) isResized() bool {
+	width, height := c.terminalSize()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return width != c.width || height != c.height
+}
+
+func (c *DashboardViewController) draw() {
+	width, height := c.terminalSize()
+
+	c.mutex.Lock()
+	c.width, c.height = width, height
+	lines := c.frame(width, height)
+	c.mutex.Unlock()
+
+	// the terminal is in raw mode, so every line has to return the carriage itself
+	fmt.Fprint(c.writer, cursorHome+strings.Join(lines, clearLine+"\r\n")+clearLine+clearBelow)
+}
+
+// frame lays out the whole screen: a title, the rollouts and the selected rollout side by side, and a status line
+func (c *DashboardViewController) frame(width int, height int) []string {
+	if width < dashboardMinWidth || height < dashboardMinHeight {
+		return []string{fitWidth(fmt.Sprintf("The terminal is too small, resize it to at least %dx%d", dashboardMinWidth, dashboardMinHeight), width)}
+	}
+
+	bodyRows := height - 2
+	listWidth := width / 3
+	if listWidth < dashboardMinListWidth {
+		listWidth = dashboardMinListWidth
+	} else if listWidth > dashboardMaxListWidth {
+		listWidth = dashboardMaxListWidth
+	}
+	rolloutWidth := width - listWidth - 1
+
+	title := fmt.Sprintf("OceanCD dashboard - %d %s, refreshed every %s", len(c.rollouts), rolloutsNoun(len(c.rollouts)), c.interval)
+	lines := []string{fitWidth(c.colorizeWith(title, color.Bold), width)}
+
+	listLines := c.rolloutListLines(bodyRows)
+	rolloutLines := c.rolloutLines(rolloutWidth, bodyRows)
+	separator := c.colorizeWith(dashboardSeparator, color.FgHiBlack)
+
+	for i := 0; i < bodyRows; i++ {
+		lines = append(lines, fitWidth(lineAt(listLines, i), listWidth)+separator+fitWidth(lineAt(rolloutLines, i), rolloutWidth))
+	}
+
+	return append(lines, fitWidth(c.statusLine(), width))
+}
+
+func (c *DashboardViewController) rolloutListLines(rows int) []string {
+	lines := []string{c.paneTitle(fmt.Sprintf("ROLLOUTS (%d)", len(c.rollouts)), c.focus == focusRollouts)}
+	if len(c.rollouts) == 0 {
+		return append(lines, "", " No active rollouts found")
+	}
+
+	// scrolls the list just enough to keep the selected rollout visible
+	visibleRows := rows - 1
+	first := 0
+	if selected := c.indexOf(c.selectedId); selected >= visibleRows {
+		first = selected - visibleRows + 1
+	}
+
+	for i := first; i < len(c.rollouts) && i < first+visibleRows; i++ {
+		rolloutItem := c.rollouts[i]
+		marker := " "
+		name := rolloutItem.SpotDeployment
+		if rolloutItem.Id == c.selectedId {
+			marker = c.colorizeWith(dashboardSelected, color.FgCyan)
+			name = c.colorizeWith(name, color.Bold)
+		}
+
+		lines = append(lines, fmt.Sprintf("%s %s %s %s", marker, c.statusIcon(rolloutItem.Status), name,
+			c.colorizeWith(fmt.Sprintf("%s/%s", rolloutItem.ClusterId, rolloutItem.Namespace), color.FgHiBlack)))
+	}
+
+	return lines
+}
+
+// rolloutLines renders the selected rollout like 'rollout get' does, followed by the scrollable verification details
+func (c *DashboardViewController) rolloutLines(width int, rows int) []string {
+	if c.selectedId == "" {
+		return nil
+	}
+
+	if c.detailedRollout == nil {
+		return []string{"", fmt.Sprintf(" Loading the rollout %s", c.selectedId)}
+	}
+
+	summary := &bytes.Buffer{}
+	fmt.Fprintf(summary, tableFormat, "Rollout:", c.detailedRollout.Id)
+	summaryController := &RolloutViewController{viewController: &viewController{writer: summary}}
+	summaryController.PrintRollout(c.detailedRollout)
+
+	// the details follow the phase statuses as adjusted by the summary, on the same printed copy
+	details := &bytes.Buffer{}
+	detailsController := &RolloutViewController{viewController: &viewController{writer: details}, rollout: summaryController.rollout}
+	detailsController.printVerificationDetails()
+
+	lines := splitLines(summary.String())
+	detailsLines := splitLines(strings.TrimLeft(details.String(), "\n"))
+	if len(detailsLines) > 0 {
+		// the title line of the verification details is replaced by the pane title
+		detailsLines = detailsLines[1:]
+	}
+
+	if len(detailsLines) == 0 {
+		c.detailsRows = 0
+		return lines
+	}
+
+	summaryRows := len(lines)
+	if summaryRows > rows-dashboardMinDetailsRows-1 {
+		summaryRows = rows - dashboardMinDetailsRows - 1
+	}
+
+	c.detailsRows = rows - summaryRows - 1
+	maxOffset := len(detailsLines) - c.detailsRows
+	if maxOffset < 0 {
+		maxOffset = 0
+	}
+
+	if c.detailsOffset > maxOffset {
+		c.detailsOffset = maxOffset
+	} else if c.detailsOffset < 0 {
+		c.detailsOffset = 0
+	}
+
+	last := c.detailsOffset + c.detailsRows
+	if last > len(detailsLines) {
+		last = len(detailsLines)
+	}
+
+	title := fmt.Sprintf("VERIFICATION DETAILS (%d-%d/%d)", c.detailsOffset+1, last, len(detailsLines))
+	retVal := append(lines[:summaryRows], fitWidth(c.paneTitle(title, c.focus == focusDetails), width))
+
+	return append(retVal, detailsLines[c.detailsOffset:last]...)
+}
+
+func (c *DashboardViewController) paneTitle(title string, focused bool) string {
+	if focused {
+		return c.colorizeWith(title, color.FgCyan)
+	}
+
+	return c.colorizeWith(title, color.Bold)
+}
+
+func (c *DashboardViewController) statusLine() string {
+	if c.confirmation != nil {
+		return c.colorizeWith(fmt.Sprintf("Confirm %s of the rollout %s? (y/n)", c.confirmation.action, c.selectedId), color.FgYellow)
+	}
+
+	if c.message != "" {
+		return fmt.Sprintf("%s  %s", c.message, c.colorizeWith("(esc to dismiss)", color.FgHiBlack))
+	}
+
+	return c.colorizeWith(dashboardHelp, color.FgHiBlack)
+}
+
+func rolloutsNoun(count int) string {
+	if count == 1 {
+		return "rollout"
+	}
+
+	return "rollouts"
+}
+
+func lineAt(lines []string, index int) string {
+	if index < len(lines) {
+		return lines[index]
+	}
+
+	return ""
+}
+
+func splitLines(text string) []string {
+	text = strings.TrimRight(strings.ReplaceAll(text, "\t", "  "), "\n")
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(text, "\n")
+}
+
+// fitWidth pads or truncates the text to exactly the given number of visible characters, ignoring color sequences
+func fitWidth(text string, width int) string {
+	builder := strings.Builder{}
+	visible := 0
+	truncated := false
+
+	for i := 0; i < len(text); {
+		if location := ansiPattern.FindStringIndex(text[i:]); location != nil && location[0] == 0 {
+			builder.WriteString(text[i : i+location[1]])
+			i += location[1]
+			continue
+		}
+
+		if visible == width-1 && visibleWidth(text[i:]) > 1 {
+			builder.WriteString(dashboardEllipsis)
+			visible++
+			truncated = true
+			break
+		}
+
+		if visible == width {
+			truncated = true
+			break
+		}
+
+		r, size := utf8.DecodeRuneInString(text[i:])
+		builder.WriteRune(r)
+		visible++
+		i += size
+	}
+
+	if truncated {
+		builder.WriteString(resetStyle)
+	}
+
+	return builder.String() + strings.Repeat(" ", width-visible)
+}
+
+func visibleWidth(text string) int {
+	return utf8.RuneCountInString(ansiPattern.ReplaceAllString(text, ""))
+}
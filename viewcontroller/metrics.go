@@ -0,0 +1,130 @@
+package viewcontroller
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"spot-oceancd-cli/pkg/oceancd/model"
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+	"spot-oceancd-cli/pkg/oceancd/model/verification"
+	"spot-oceancd-cli/viewcontroller/chart"
+	"spot-oceancd-cli/viewcontroller/converter"
+	"strings"
+)
+
+type metricsView struct {
+	metricName  string
+	chartHeight int
+}
+
+// WithMetrics renders the verification data points as charts instead of the rollout table.
+// An empty metric name renders every metric, a chart height below 2 renders sparklines.
+func (c *RolloutViewController) WithMetrics(metricName string, chartHeight int) *RolloutViewController {
+	c.metrics = &metricsView{metricName: metricName, chartHeight: chartHeight}
+	return c
+}
+
+// PrintMetrics prints a copy of the rollout, as printing orders the verifications
+func (c *RolloutViewController) PrintMetrics(detailedRollout *rollout.DetailedRollout) {
+	c.rollout = detailedRollout.DeepCopy()
+	fmt.Fprintf(c.writer, tableFormat, "Rollout:", c.rollout.Id)
+	fmt.Fprintf(c.writer, tableFormat, "SpotDeploymentName:", c.rollout.SpotDeployment)
+	fmt.Fprintf(c.writer, tableFormat, "Status:", fmt.Sprintf("%s %s", c.statusIcon(c.rollout.Status), converter.RolloutStatus(c.rollout.Status)))
+
+	printed := 0
+	for i, rolloutPhase := range c.rollout.Phases {
+		for _, verificationItem := range c.orderVerifications(rolloutPhase.Verifications) {
+			if c.printMetric(fmt.Sprintf("%s %s", converter.PhaseIndex(i+1), converter.PhaseName(rolloutPhase)), verificationItem) {
+				printed++
+			}
+		}
+	}
+
+	for _, verificationItem := range c.orderVerifications(c.rollout.GetBackgroundVerifications()) {
+		if c.printMetric(model.BackgroundVerificationLabel, verificationItem) {
+			printed++
+		}
+	}
+
+	if printed > 0 {
+		return
+	}
+
+	if c.metrics.metricName != "" {
+		fmt.Fprintf(c.writer, "\nNo verifications found for metric %s\n", c.metrics.metricName)
+	} else {
+		fmt.Fprintf(c.writer, "\n%s\n", "No verifications found")
+	}
+}
+
+func (c *RolloutViewController) printMetric(step string, verificationItem verification.Verification) bool {
+	if c.metrics.metricName != "" && verificationItem.MetricName != c.metrics.metricName {
+		return false
+	}
+
+	fmt.Fprintf(c.writer, "\n%s / %s (%s) %s %s\n",
+		c.colorizeWith(step, color.Bold), c.colorizeWith(verificationItem.MetricName, color.Bold), verificationItem.Provider,
+		c.verificationStatusIcon(verificationItem.Status), converter.VerificationStatus(verificationItem))
+
+	series := chart.Series{}
+	if threshold, ok := chart.ParseThreshold(verificationItem.FailureCondition); ok {
+		series.Threshold = threshold
+	}
+
+	for _, dataPoint := range verificationItem.DataPoints {
+		value, ok := chart.ParseValue(dataPoint.Value)
+		if ok == false {
+			continue
+		}
+
+		series.Add(value, isFailedDataPoint(dataPoint, value, series.Threshold))
+	}
+
+	if len(series.Values) == 0 {
+		fmt.Fprintf(c.writer, "%s%s\n", subRowOffset, "No data points measured yet")
+		return true
+	}
+
+	min, max := series.Bounds()
+	summary := []string{
+		fmt.Sprintf("Last: %s", chart.FormatValue(series.Values[len(series.Values)-1])),
+		fmt.Sprintf("Points: %d", len(series.Values)),
+	}
+
+	if series.Threshold != nil {
+		summary = append([]string{fmt.Sprintf("FailureCondition: %s", verificationItem.FailureCondition)}, summary...)
+	}
+
+	fmt.Fprintf(c.writer, "%s%s\n", subRowOffset, strings.Join(summary, " | "))
+
+	highlight := func(text string) string {
+		return c.colorizeWith(text, color.FgRed)
+	}
+
+	if c.metrics.chartHeight < 2 {
+		sparkline := chart.Sparkline(series, highlight)
+		fmt.Fprintf(c.writer, "%s%s  %s..%s\n", subRowOffset, sparkline, chart.FormatValue(min), chart.FormatValue(max))
+		return true
+	}
+
+	thresholdStyle := func(text string) string {
+		return c.colorizeWith(text, color.FgYellow)
+	}
+
+	for _, row := range chart.LineChart(series, c.metrics.chartHeight, highlight, thresholdStyle) {
+		fmt.Fprintf(c.writer, "%s%s\n", subRowOffset, row)
+	}
+
+	return true
+}
+
+// isFailedDataPoint trusts the measured status and falls back to the failure condition for points without one
+func isFailedDataPoint(dataPoint verification.DataPoint, value float64, threshold *chart.Threshold) bool {
+	switch verification.Status(dataPoint.Status) {
+	case verification.Failed, verification.Error:
+		return true
+	case "":
+		return threshold != nil && threshold.IsBreachedBy(value)
+	default:
+		return false
+	}
+}
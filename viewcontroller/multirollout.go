@@ -0,0 +1,326 @@
+package viewcontroller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/fatih/color"
+	"io"
+	"os"
+	"reflect"
+	"spot-oceancd-cli/pkg/oceancd/model"
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+	"spot-oceancd-cli/pkg/oceancd/repositories"
+	"spot-oceancd-cli/viewcontroller/converter"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+const summaryTemplate = "%s\t%s\t%s\t%s\t%s\t%s\t%s\n"
+
+// MultiRolloutViewController watches several rollouts concurrently and renders one summary line per rollout,
+// or the phases of every rollout stacked one below the other
+type MultiRolloutViewController struct {
+	*viewController
+	controllers []*RolloutViewController
+	rollouts    []*rollout.DetailedRollout
+	errors      []error
+	mutex       sync.Mutex
+	stacked     bool
+	details     bool
+	output      string
+	failFast    bool
+	watching    bool
+	interval    time.Duration
+	changes     chan struct{}
+}
+
+func NewMultiRolloutViewController(repository repositories.RolloutRepository, rolloutIds []string, noColor bool) *MultiRolloutViewController {
+	vc := newViewController(noColor)
+	controllers := make([]*RolloutViewController, len(rolloutIds))
+	for i, rolloutId := range rolloutIds {
+		controllers[i] = NewRolloutViewController(repository, rolloutId, noColor)
+	}
+
+	return &MultiRolloutViewController{
+		viewController: vc,
+		controllers:    controllers,
+		rollouts:       make([]*rollout.DetailedRollout, len(rolloutIds)),
+		errors:         make([]error, len(rolloutIds)),
+		changes:        make(chan struct{}, 1),
+	}
+}
+
+// WithStacked renders the phases of every rollout instead of a single summary line
+func (c *MultiRolloutViewController) WithStacked(stacked bool) *MultiRolloutViewController {
+	c.stacked = stacked
+	return c
+}
+
+// WithDetails adds the verification details to the stacked view
+func (c *MultiRolloutViewController) WithDetails(details bool) *MultiRolloutViewController {
+	c.details = details
+	return c
+}
+
+// WithOutput renders every rollout as a json or yaml document
+func (c *MultiRolloutViewController) WithOutput(output string) *MultiRolloutViewController {
+	for _, controller := range c.controllers {
+		controller.WithOutput(output)
+	}
+
+	c.output = output
+	return c
+}
+
+// WithInterval sets the base interval between refreshes of every rollout while watching
+func (c *MultiRolloutViewController) WithInterval(interval time.Duration) *MultiRolloutViewController {
+	c.interval = interval
+	return c
+}
+
+// WithFailFast stops watching as soon as one of the rollouts fails, is aborted or has an invalid spec
+func (c *MultiRolloutViewController) WithFailFast(failFast bool) *MultiRolloutViewController {
+	c.failFast = failFast
+	return c
+}
+
+// Fetch gets every rollout once, concurrently. The first error is returned after all of them are fetched.
+func (c *MultiRolloutViewController) Fetch(ctx context.Context) error {
+	wg := &sync.WaitGroup{}
+	for i := range c.controllers {
+		wg.Add(1)
+
+		go func(index int) {
+			defer wg.Done()
+			c.fetch(ctx, index)
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i, err := range c.errors {
+		if err != nil {
+			return fmt.Errorf("rollout %s: %w", c.controllers[i].rolloutId, err)
+		}
+	}
+
+	return nil
+}
+
+// Run watches every rollout until all of them are completed, one of them fails with fail fast, or the context is done
+func (c *MultiRolloutViewController) Run(ctx context.Context) {
+	c.watching = true
+
+	// the rollouts fetched so far are rendered right away, so completed ones are shown before returning
+	select {
+	case <-c.changes:
+	default:
+	}
+
+	c.render()
+	if c.isDone() {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+	defer cancel()
+
+	for i := range c.controllers {
+		wg.Add(1)
+
+		// every rollout is refreshed at its own pace and no longer once it is done
+		go func(index int) {
+			defer wg.Done()
+			pollAdaptively(ctx, c.interval, nil, func() (bool, bool) {
+				changed := c.fetch(ctx, index)
+				detailedRollout := c.Rollouts()[index]
+
+				return changed, detailedRollout != nil && detailedRollout.Status.IsDone()
+			})
+		}(i)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.changes:
+			c.render()
+			if c.isDone() {
+				return
+			}
+		}
+	}
+}
+
+// Rollouts returns the latest observed state of every rollout, nil for the ones not fetched yet
+func (c *MultiRolloutViewController) Rollouts() []*rollout.DetailedRollout {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return append([]*rollout.DetailedRollout{}, c.rollouts...)
+}
+
+// fetch refreshes a single rollout and reports whether it changed
+func (c *MultiRolloutViewController) fetch(ctx context.Context, index int) bool {
+	controller := c.controllers[index]
+	detailedRollout, err := controller.GetRollout(ctx)
+	if err != nil && ctx.Err() != nil {
+		return false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err != nil {
+		changed := c.errors[index] == nil || c.errors[index].Error() != err.Error()
+		c.errors[index] = err
+		if changed {
+			c.notify()
+		}
+		return false
+	}
+
+	controller.previousRollout = detailedRollout
+	changed := c.errors[index] != nil || reflect.DeepEqual(c.rollouts[index], detailedRollout) == false
+	c.errors[index] = nil
+	c.rollouts[index] = detailedRollout
+	if changed {
+		c.notify()
+	}
+
+	return changed
+}
+
+func (c *MultiRolloutViewController) notify() {
+	select {
+	case c.changes <- struct{}{}:
+	default:
+	}
+}
+
+func (c *MultiRolloutViewController) isDone() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	completed := 0
+	for _, detailedRollout := range c.rollouts {
+		if detailedRollout == nil {
+			continue
+		}
+
+		if c.failFast && detailedRollout.Status.IsUnsuccessful() {
+			return true
+		}
+
+		if detailedRollout.Status.IsDone() {
+			completed++
+		}
+	}
+
+	return completed == len(c.rollouts)
+}
+
+func (c *MultiRolloutViewController) render() {
+	if c.output == "" {
+		fmt.Fprint(c.writer, "\033[H\033[2J")
+		fmt.Fprint(c.writer, "\033[0;0H")
+	}
+
+	c.Print()
+}
+
+// Print renders the latest observed state of the rollouts
+func (c *MultiRolloutViewController) Print() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.output != "" {
+		for i, detailedRollout := range c.rollouts {
+			if detailedRollout == nil {
+				continue
+			}
+
+			if err := c.controllers[i].PrintRolloutDocument(detailedRollout, c.watching); err != nil {
+				fmt.Fprintf(os.Stderr, "%s\n", err)
+			}
+		}
+
+		c.printErrors(os.Stderr)
+		return
+	}
+
+	if c.stacked {
+		for i := range c.rollouts {
+			c.printStackedRollout(i)
+		}
+	} else {
+		c.printSummary()
+	}
+
+	c.printErrors(c.writer)
+}
+
+func (c *MultiRolloutViewController) printSummary() {
+	writer := tabwriter.NewWriter(c.writer, 0, 0, 2, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(writer, summaryTemplate, c.colorize("ROLLOUT"), c.colorize("SPOTDEPLOYMENT"), c.colorize("CLUSTER"),
+		c.colorize("NAMESPACE"), c.colorize("STATUS"), c.colorize("PHASES"), c.colorize("ACTIVE PHASE"))
+
+	for i, detailedRollout := range c.rollouts {
+		if detailedRollout == nil {
+			fmt.Fprintf(writer, summaryTemplate, c.controllers[i].rolloutId, "", "", "", "Loading", "", "")
+			continue
+		}
+
+		fmt.Fprintf(writer, summaryTemplate, detailedRollout.Id, detailedRollout.SpotDeployment, detailedRollout.ClusterId,
+			detailedRollout.Namespace, fmt.Sprintf("%s %s", c.statusIcon(detailedRollout.Status), converter.RolloutStatus(detailedRollout.Status)),
+			model.Stub(detailedRollout.PhaseProgress()), c.activePhase(detailedRollout))
+	}
+
+	writer.Flush()
+}
+
+func (c *MultiRolloutViewController) activePhase(detailedRollout *rollout.DetailedRollout) string {
+	if len(detailedRollout.Phases) == 0 || detailedRollout.Status.IsCompleted() {
+		return model.Stub("")
+	}
+
+	activePhase := detailedRollout.Phases[detailedRollout.ActivePhase()-1]
+
+	return fmt.Sprintf("%s %s %s", converter.PhaseName(activePhase), c.phaseStatusIcon(activePhase.Status), converter.PhaseStatus(activePhase))
+}
+
+func (c *MultiRolloutViewController) printStackedRollout(index int) {
+	detailedRollout := c.rollouts[index]
+	if detailedRollout == nil {
+		fmt.Fprintf(c.writer, "%s %s\n\n", c.colorizeWith(c.controllers[index].rolloutId, color.Bold), "Loading")
+		return
+	}
+
+	fmt.Fprintf(c.writer, "%s %s %s %s/%s %s\n", c.statusIcon(detailedRollout.Status), c.colorizeWith(detailedRollout.Id, color.Bold),
+		c.colorizeWith(detailedRollout.SpotDeployment, color.Bold), detailedRollout.ClusterId, detailedRollout.Namespace,
+		fmt.Sprintf("%s, phases: %s", converter.RolloutStatus(detailedRollout.Status), model.Stub(detailedRollout.PhaseProgress())))
+
+	// printing adjusts the phase statuses and orders the verifications, the observed rollout is kept as fetched
+	buffer := &bytes.Buffer{}
+	controller := &RolloutViewController{viewController: &viewController{writer: buffer}, rollout: detailedRollout.DeepCopy()}
+	controller.printPhases()
+	if c.details {
+		controller.printVerificationDetails()
+	}
+
+	fmt.Fprintf(c.writer, "%s\n\n", strings.TrimSpace(buffer.String()))
+}
+
+func (c *MultiRolloutViewController) printErrors(writer io.Writer) {
+	for i, err := range c.errors {
+		if err != nil {
+			fmt.Fprintf(writer, "Failed to get the rollout %s - %s\n", c.controllers[i].rolloutId, err.Error())
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package viewcontroller
+
+import (
+	"bytes"
+	"context"
+	"github.com/google/go-cmp/cmp"
+	"spot-oceancd-cli/pkg/oceancd"
+	"spot-oceancd-cli/pkg/oceancd/model/phase"
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+	"spot-oceancd-cli/pkg/oceancd/model/verification"
+	"spot-oceancd-cli/pkg/oceancd/repositories/repositoriestest"
+	"strings"
+	"testing"
+)
+
+func newFakeRolloutRepository() *repositoriestest.FakeRolloutRepository {
+	repository := repositoriestest.NewFakeRolloutRepository()
+	definition := map[string]interface{}{
+		"strategy": map[string]interface{}{
+			"canary": map[string]interface{}{
+				"steps": []interface{}{map[string]interface{}{"name": "first"}, map[string]interface{}{"name": "second"}},
+			},
+		},
+	}
+
+	repository.PutRollout(rollout.DetailedRollout{
+		Rollout: rollout.Rollout{Id: "rol-eu", Status: rollout.InProgress, SpotDeployment: "checkout", ClusterId: "prod-eu", Namespace: "shop"},
+		Phases:  []phase.Phase{{Name: "first", Status: phase.Finished}, {Name: "second", Status: phase.InProgress}},
+	}, definition)
+	repository.PutRollout(rollout.DetailedRollout{
+		Rollout: rollout.Rollout{Id: "rol-us", Status: rollout.Finished, SpotDeployment: "checkout", ClusterId: "prod-us", Namespace: "shop"},
+		Phases:  []phase.Phase{{Name: "first", Status: phase.Finished}, {Name: "second", Status: phase.Finished}},
+	}, definition)
+
+	return repository
+}
+
+func TestMultiRolloutViewControllerPrint(t *testing.T) {
+	cases := map[string]struct {
+		rolloutIds []string
+		failWith   string
+		expectErr  bool
+		expected   []string
+	}{
+		"every rollout": {
+			rolloutIds: []string{"rol-eu", "rol-us"},
+			expected: []string{
+				"ROLLOUT  SPOTDEPLOYMENT  CLUSTER  NAMESPACE  STATUS         PHASES  ACTIVE PHASE",
+				"rol-eu   checkout        prod-eu  shop       ◷ In progress  2/2     second ◷ In progress",
+				"rol-us   checkout        prod-us  shop       ✔ Finished     2       --",
+			},
+		},
+		"unknown rollout": {
+			rolloutIds: []string{"rol-eu", "rol-missing"},
+			expectErr:  true,
+			expected: []string{
+				"ROLLOUT      SPOTDEPLOYMENT  CLUSTER  NAMESPACE  STATUS         PHASES  ACTIVE PHASE",
+				"rol-eu       checkout        prod-eu  shop       ◷ In progress  2/2     second ◷ In progress",
+				"rol-missing                                      Loading",
+				"Failed to get the rollout rol-missing - error: Failed to get the rollout status - Rollout rol-missing does not exist",
+				"error: Failed to get the rollout phases - Rollout rol-missing does not exist",
+				"error: Failed to get the rollout verifications - Rollout rol-missing does not exist",
+				"error: Failed to get the rollout definition - Rollout rol-missing does not exist",
+			},
+		},
+		"failed definition": {
+			rolloutIds: []string{"rol-us"},
+			failWith:   "GetRolloutDefinition",
+			expectErr:  true,
+			expected: []string{
+				"ROLLOUT  SPOTDEPLOYMENT  CLUSTER  NAMESPACE  STATUS   PHASES  ACTIVE PHASE",
+				"rol-us                                       Loading",
+				"Failed to get the rollout rol-us - error: Failed to get the rollout definition - Service unavailable",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			repository := newFakeRolloutRepository()
+			if tc.failWith != "" {
+				repository.FailWith(tc.failWith, &oceancd.APIError{Messages: []string{"Service unavailable"}})
+			}
+
+			controller := NewMultiRolloutViewController(repository, tc.rolloutIds, true)
+			buffer := &bytes.Buffer{}
+			controller.writer = buffer
+
+			err := controller.Fetch(context.Background())
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			controller.Print()
+
+			actual := strings.Split(strings.TrimRight(buffer.String(), "\n"), "\n")
+			for i := range actual {
+				actual[i] = strings.TrimRight(actual[i], " ")
+			}
+
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("mismatch (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDashboardViewControllerSendAction(t *testing.T) {
+	repository := newFakeRolloutRepository()
+	controller := NewDashboardViewController(repository, oceancd.RolloutFilter{ClusterId: "prod-eu"}, true)
+
+	controller.refresh(context.Background())
+	if controller.selectedId != "rol-eu" {
+		t.Fatalf("expected the rollout of the cluster to be selected, got %q", controller.selectedId)
+	}
+
+	controller.sendAction(context.Background(), dashboardAction{action: oceancd.PromoteAction}, controller.selectedId)
+
+	expected := []repositoriestest.FakeRolloutAction{{RolloutId: "rol-eu", Action: oceancd.PromoteAction}}
+	if diff := cmp.Diff(expected, repository.Actions()); diff != "" {
+		t.Errorf("mismatch (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestMultiRolloutViewControllerFetchUnchangedRollout(t *testing.T) {
+	repository := newFakeRolloutRepository()
+	repository.PutRollout(rollout.DetailedRollout{
+		Rollout: rollout.Rollout{Id: "rol-verified", Status: rollout.InProgress, SpotDeployment: "checkout"},
+		Phases: []phase.Phase{
+			{Name: "first", Status: phase.FullPromoted, Verifications: []verification.Verification{
+				{MetricName: "latency", Status: verification.Successful},
+				{MetricName: "errors", Status: verification.Failed},
+			}},
+			{Name: "second", Status: phase.Pending},
+		},
+	}, map[string]interface{}{
+		"strategy": map[string]interface{}{
+			"canary": map[string]interface{}{
+				"steps": []interface{}{map[string]interface{}{"name": "first"}, map[string]interface{}{"name": "second"}},
+			},
+		},
+	})
+
+	controller := NewMultiRolloutViewController(repository, []string{"rol-verified"}, true).WithStacked(true).WithDetails(true)
+	controller.writer = &bytes.Buffer{}
+
+	if controller.fetch(context.Background(), 0) == false {
+		t.Fatal("expected the first fetch to be a change")
+	}
+
+	controller.Print()
+
+	if controller.fetch(context.Background(), 0) {
+		t.Errorf("expected the printed rollout not to count as changed")
+	}
+}
+
+func TestDashboardViewControllerRolloutLinesKeepRollout(t *testing.T) {
+	repository := newFakeRolloutRepository()
+	repository.PutRollout(rollout.DetailedRollout{
+		Rollout: rollout.Rollout{Id: "rol-verified", Status: rollout.InProgress, SpotDeployment: "checkout", ClusterId: "prod-ap", Namespace: "shop"},
+		Phases: []phase.Phase{
+			{Name: "first", Status: phase.FullPromoted, Verifications: []verification.Verification{
+				{MetricName: "latency", Status: verification.Successful},
+				{MetricName: "errors", Status: verification.Failed},
+			}},
+			{Name: "second", Status: phase.Pending},
+		},
+	}, map[string]interface{}{
+		"strategy": map[string]interface{}{
+			"canary": map[string]interface{}{
+				"steps": []interface{}{map[string]interface{}{"name": "first"}, map[string]interface{}{"name": "second"}},
+			},
+		},
+	})
+
+	controller := NewDashboardViewController(repository, oceancd.RolloutFilter{ClusterId: "prod-ap"}, true)
+	controller.refresh(context.Background())
+	if controller.detailedRollout == nil {
+		t.Fatal("expected the rollout of the cluster to be fetched")
+	}
+
+	expected := controller.detailedRollout.DeepCopy()
+	if lines := controller.rolloutLines(80, 40); len(lines) == 0 {
+		t.Fatal("expected the rollout to be rendered")
+	}
+
+	if diff := cmp.Diff(expected, controller.detailedRollout); diff != "" {
+		t.Errorf("expected the fetched rollout to be left as is (-expected +actual):\n%s", diff)
+	}
+}
@@ -0,0 +1,87 @@
+package viewcontroller
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	DefaultWatchInterval = 2 * time.Second
+
+	// the interval grows up to this factor of the base interval while nothing changes
+	maxIntervalFactor = 8
+	maxWatchInterval  = 30 * time.Second
+)
+
+// backoff doubles the polling interval every time nothing changed and goes back to the base interval on a change
+type backoff struct {
+	base    time.Duration
+	max     time.Duration
+	current time.Duration
+}
+
+func newBackoff(base time.Duration) *backoff {
+	if base <= 0 {
+		base = DefaultWatchInterval
+	}
+
+	max := base * maxIntervalFactor
+	if max > maxWatchInterval {
+		max = maxWatchInterval
+	}
+
+	if max < base {
+		max = base
+	}
+
+	return &backoff{base: base, max: max, current: base}
+}
+
+func (b *backoff) next(changed bool) time.Duration {
+	if changed {
+		b.current = b.base
+		return b.current
+	}
+
+	b.current *= 2
+	if b.current > b.max {
+		b.current = b.max
+	}
+
+	return b.current
+}
+
+func (b *backoff) reset() {
+	b.current = b.base
+}
+
+// pollAdaptively calls fetch after every interval until it reports done or the context is done.
+// A value sent to refresh triggers an immediate fetch and resets the interval, e.g. after a rollout action.
+func pollAdaptively(ctx context.Context, interval time.Duration, refresh <-chan struct{}, fetch func() (changed bool, done bool)) {
+	intervals := newBackoff(interval)
+	timer := time.NewTimer(intervals.current)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		case <-refresh:
+			intervals.reset()
+			if timer.Stop() == false {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		}
+
+		changed, done := fetch()
+		if done {
+			return
+		}
+
+		timer.Reset(intervals.next(changed))
+	}
+}
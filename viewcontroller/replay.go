@@ -0,0 +1,92 @@
+package viewcontroller
+
+import (
+	"context"
+	"spot-oceancd-cli/pkg/oceancd/repositories"
+	"time"
+)
+
+// ReplayViewController plays a recorded session back through the same rendering as the live watch
+type ReplayViewController struct {
+	*RolloutViewController
+	session *repositories.ReplayRolloutRepository
+	speed   float64
+}
+
+func NewReplayViewController(snapshots []repositories.Snapshot, noColor bool) *ReplayViewController {
+	session := repositories.NewReplayRolloutRepository(snapshots)
+
+	return &ReplayViewController{
+		RolloutViewController: NewRolloutViewController(session, snapshots[0].Rollout.Id, noColor),
+		session:               session,
+		speed:                 1,
+	}
+}
+
+// WithSpeed shortens the time between the snapshots by the given factor, e.g. 10 plays the session ten times faster
+func (c *ReplayViewController) WithSpeed(speed float64) *ReplayViewController {
+	if speed > 0 {
+		c.speed = speed
+	}
+
+	return c
+}
+
+// Run renders every snapshot at the time it was observed, relative to the first one, until the last one or the context is done
+func (c *ReplayViewController) Run(ctx context.Context) error {
+	snapshots := c.session.Snapshots()
+	observedAt := make([]time.Time, len(snapshots))
+	for i, snapshot := range snapshots {
+		observedAt[i] = snapshot.ObservedAt
+	}
+
+	for index := range newScaledTicker(ctx, observedAt, c.speed) {
+		c.session.Seek(index)
+
+		detailedRollout, err := c.GetRollout(ctx)
+		if err != nil {
+			return err
+		}
+
+		c.render(detailedRollout)
+
+		c.mutex.Lock()
+		c.previousRollout = detailedRollout
+		c.mutex.Unlock()
+	}
+
+	return ctx.Err()
+}
+
+// newScaledTicker sends the index of every point in time once the scaled time since the previous one elapsed.
+// The channel is closed after the last index, or as soon as the context is done.
+func newScaledTicker(ctx context.Context, times []time.Time, speed float64) <-chan int {
+	ticks := make(chan int)
+
+	go func() {
+		defer close(ticks)
+
+		for i := range times {
+			if i > 0 {
+				wait := time.Duration(float64(times[i].Sub(times[i-1])) / speed)
+				if wait > 0 {
+					timer := time.NewTimer(wait)
+					select {
+					case <-ctx.Done():
+						timer.Stop()
+						return
+					case <-timer.C:
+					}
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case ticks <- i:
+			}
+		}
+	}()
+
+	return ticks
+}
@@ -0,0 +1,236 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"spot-oceancd-cli/pkg/oceancd/model"
+	"spot-oceancd-cli/pkg/oceancd/model/phase"
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+	"spot-oceancd-cli/pkg/oceancd/model/strategy"
+	"spot-oceancd-cli/pkg/oceancd/model/verification"
+	"spot-oceancd-cli/viewcontroller/converter"
+	"strings"
+)
+
+const (
+	FormatMarkdown = "markdown"
+	FormatHtml     = "html"
+)
+
+var Formats = []string{FormatMarkdown, FormatHtml}
+
+// Report is the timeline of a rollout. It holds no time of its own, e.g. when it was rendered,
+// so the same rollout always renders the same report.
+type Report struct {
+	RolloutId               string
+	SpotDeployment          string
+	ClusterId               string
+	Namespace               string
+	Strategy                string
+	Status                  string
+	StartTime               string
+	EndTime                 string
+	Duration                string
+	Versions                []Version
+	Phases                  []Phase
+	BackgroundVerifications []Verification
+	HeaderRoutes            []HeaderRoute
+}
+
+type Version struct {
+	Name              string
+	Version           string
+	K8sService        string
+	TrafficPercentage int
+	Replicas          rollout.ReplicasInfo
+}
+
+type Phase struct {
+	Index         string
+	Name          string
+	Status        string
+	Weight        string
+	StartTime     string
+	PausedAt      string
+	VerifiedAt    string
+	EndTime       string
+	Duration      string
+	Verifications []Verification
+}
+
+type Verification struct {
+	Step             string
+	MetricName       string
+	Provider         string
+	Status           string
+	Query            string
+	FailureCondition string
+	Failures         string
+	DataPoints       []verification.DataPoint
+}
+
+type HeaderRoute struct {
+	Step       string
+	HeaderName string
+	Match      string
+}
+
+func New(detailedRollout *rollout.DetailedRollout) *Report {
+	strategyLabel := model.CanaryLabel
+	newVersionLabel := model.CanaryLabel
+	oldVersionLabel := model.StableLabel
+
+	if detailedRollout.Strategy == model.RollingUpdateStrategyType {
+		strategyLabel = model.RollingUpdateStrategyTypeLabel
+		newVersionLabel = model.NewVersionLabel
+		oldVersionLabel = model.OldVersionLabel
+	}
+
+	report := &Report{
+		RolloutId:      detailedRollout.Id,
+		SpotDeployment: detailedRollout.SpotDeployment,
+		ClusterId:      detailedRollout.ClusterId,
+		Namespace:      detailedRollout.Namespace,
+		Strategy:       strategyLabel,
+		Status:         converter.RolloutStatus(detailedRollout.Status),
+		StartTime:      model.Stub(detailedRollout.StartTime),
+		EndTime:        model.Stub(detailedRollout.EndTime),
+		Duration:       duration(detailedRollout.StartTime, detailedRollout.EndTime),
+		Versions: []Version{
+			newVersion(newVersionLabel, detailedRollout.NewVersionStatus),
+			newVersion(oldVersionLabel, detailedRollout.StableVersionStatus),
+		},
+	}
+
+	for i, rolloutPhase := range detailedRollout.Phases {
+		report.Phases = append(report.Phases, newPhase(i, rolloutPhase))
+	}
+
+	for _, verificationItem := range sortedVerifications(detailedRollout.GetBackgroundVerifications()) {
+		report.BackgroundVerifications = append(report.BackgroundVerifications, newVerification(model.BackgroundVerificationLabel, verificationItem))
+	}
+
+	if detailedRollout.Definition.Strategy != nil {
+		report.HeaderRoutes = newHeaderRoutes(detailedRollout.Phases, detailedRollout.Definition.Strategy.GetHeaderRouteMatchesBySteps())
+	}
+
+	return report
+}
+
+// Render writes the report in the given format, one of Formats
+func (r *Report) Render(writer io.Writer, format string) error {
+	switch format {
+	case FormatMarkdown:
+		return markdownTemplate.Execute(writer, r)
+	case FormatHtml:
+		return htmlTemplate.Execute(writer, r)
+	default:
+		return fmt.Errorf("error: Unknown report format %s", format)
+	}
+}
+
+func newVersion(name string, versionStatus rollout.VersionStatus) Version {
+	return Version{
+		Name:              name,
+		Version:           model.Stub(versionStatus.Version),
+		K8sService:        model.Stub(versionStatus.K8sService),
+		TrafficPercentage: versionStatus.TrafficPercentage,
+		Replicas:          versionStatus.Replicas,
+	}
+}
+
+func newPhase(index int, rolloutPhase phase.Phase) Phase {
+	step := fmt.Sprintf("%s %s", converter.PhaseIndex(index+1), converter.PhaseName(rolloutPhase))
+	reportPhase := Phase{
+		Index:      converter.PhaseIndex(index + 1),
+		Name:       converter.PhaseName(rolloutPhase),
+		Status:     converter.PhaseStatus(rolloutPhase),
+		Weight:     converter.Weight(rolloutPhase),
+		StartTime:  model.Stub(rolloutPhase.StartTime),
+		PausedAt:   model.Stub(rolloutPhase.PausedAt),
+		VerifiedAt: model.Stub(rolloutPhase.VerifiedAt),
+		EndTime:    model.Stub(rolloutPhase.EndTime),
+		Duration:   duration(rolloutPhase.StartTime, rolloutPhase.EndTime),
+	}
+
+	for _, verificationItem := range sortedVerifications(rolloutPhase.Verifications) {
+		reportPhase.Verifications = append(reportPhase.Verifications, newVerification(step, verificationItem))
+	}
+
+	return reportPhase
+}
+
+func newVerification(step string, verificationItem verification.Verification) Verification {
+	return Verification{
+		Step:             step,
+		MetricName:       verificationItem.MetricName,
+		Provider:         model.Stub(verificationItem.Provider),
+		Status:           converter.VerificationStatus(verificationItem),
+		Query:            verificationItem.Query,
+		FailureCondition: verificationItem.FailureCondition,
+		Failures:         fmt.Sprintf("%d of %d allowed", verificationItem.FailedMeasurements(), verificationItem.FailureLimit),
+		DataPoints:       verificationItem.DataPoints,
+	}
+}
+
+// newHeaderRoutes lists the matches in the order of the phases, followed by the steps without a phase sorted by name
+func newHeaderRoutes(phases []phase.Phase, matchesBySteps map[string][]strategy.Match) []HeaderRoute {
+	var steps []string
+	listed := map[string]bool{}
+	for _, rolloutPhase := range phases {
+		if listed[rolloutPhase.Name] == false {
+			steps = append(steps, rolloutPhase.Name)
+			listed[rolloutPhase.Name] = true
+		}
+	}
+
+	var remainingSteps []string
+	for step := range matchesBySteps {
+		if listed[step] == false {
+			remainingSteps = append(remainingSteps, step)
+		}
+	}
+
+	sort.Strings(remainingSteps)
+
+	var headerRoutes []HeaderRoute
+	for _, step := range append(steps, remainingSteps...) {
+		for _, match := range matchesBySteps[step] {
+			headerRoutes = append(headerRoutes, HeaderRoute{Step: step, HeaderName: match.HeaderName, Match: matchValue(match.HeaderValue)})
+		}
+	}
+
+	return headerRoutes
+}
+
+func matchValue(headerValue strategy.HeaderValue) string {
+	var values []string
+	if headerValue.Exact != "" {
+		values = append(values, fmt.Sprintf("exact: %s", headerValue.Exact))
+	}
+
+	if headerValue.Prefix != "" {
+		values = append(values, fmt.Sprintf("prefix: %s", headerValue.Prefix))
+	}
+
+	if headerValue.Regex != "" {
+		values = append(values, fmt.Sprintf("regex: %s", headerValue.Regex))
+	}
+
+	return model.Stub(strings.Join(values, ", "))
+}
+
+// sortedVerifications orders the verifications by metric name without reordering the given ones
+func sortedVerifications(verifications []verification.Verification) []verification.Verification {
+	sorted := append([]verification.Verification{}, verifications...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].MetricName < sorted[j].MetricName
+	})
+
+	return sorted
+}
+
+func duration(startTime string, endTime string) string {
+	return model.Stub(rollout.Duration(startTime, endTime))
+}
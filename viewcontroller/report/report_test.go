@@ -0,0 +1,101 @@
+package report
+
+import (
+	"bytes"
+	"github.com/google/go-cmp/cmp"
+	"spot-oceancd-cli/pkg/oceancd/model/phase"
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+	"spot-oceancd-cli/pkg/oceancd/model/strategy"
+	"testing"
+)
+
+func TestDuration(t *testing.T) {
+	cases := map[string]struct {
+		startTime string
+		endTime   string
+		expected  string
+	}{
+		"finished":          {startTime: "2026-10-18T02:00:00Z", endTime: "2026-10-18T02:47:12Z", expected: "47m12s"},
+		"in progress":       {startTime: "2026-10-18T02:00:00Z", endTime: "", expected: "--"},
+		"not started":       {startTime: "", endTime: "", expected: "--"},
+		"end before start":  {startTime: "2026-10-18T02:00:00Z", endTime: "2026-10-18T01:00:00Z", expected: "--"},
+		"sub-second rounds": {startTime: "2026-10-18T02:00:00.200Z", endTime: "2026-10-18T02:00:01.900Z", expected: "2s"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if actual := duration(tc.startTime, tc.endTime); actual != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRenderIsDeterministic(t *testing.T) {
+	canary := &strategy.CanaryStrategy{Steps: []strategy.Step{
+		{Name: "zeta", SetHeaderRoute: strategy.SetHeaderRoute{Match: []strategy.Match{{HeaderName: "x-zeta", HeaderValue: strategy.HeaderValue{Exact: "1"}}}}},
+		{Name: "alpha", SetHeaderRoute: strategy.SetHeaderRoute{Match: []strategy.Match{{HeaderName: "x-alpha", HeaderValue: strategy.HeaderValue{Prefix: "a"}}}}},
+		{Name: "canary", SetHeaderRoute: strategy.SetHeaderRoute{Match: []strategy.Match{{HeaderName: "x-beta", HeaderValue: strategy.HeaderValue{Regex: "b|c"}}}}},
+	}}
+
+	detailedRollout := &rollout.DetailedRollout{
+		Rollout:    rollout.Rollout{Id: "rol-a78dsds9s", Status: rollout.Finished},
+		Definition: rollout.Definition{Strategy: canary},
+		Phases:     []phase.Phase{{Name: "canary", Status: phase.Finished, TrafficPercentage: 20}},
+	}
+
+	// the header routes follow the phases, then the remaining steps by name, whatever the map order is
+	expected := []HeaderRoute{
+		{Step: "canary", HeaderName: "x-beta", Match: "regex: b|c"},
+		{Step: "alpha", HeaderName: "x-alpha", Match: "prefix: a"},
+		{Step: "zeta", HeaderName: "x-zeta", Match: "exact: 1"},
+	}
+
+	for _, format := range Formats {
+		var first bytes.Buffer
+		if err := New(detailedRollout).Render(&first, format); err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < 10; i++ {
+			reportItem := New(detailedRollout)
+			if diff := cmp.Diff(expected, reportItem.HeaderRoutes); diff != "" {
+				t.Fatalf("header routes mismatch (-expected +actual):\n%s", diff)
+			}
+
+			var next bytes.Buffer
+			if err := reportItem.Render(&next, format); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(first.String(), next.String()); diff != "" {
+				t.Fatalf("%s report changed between renders (-first +next):\n%s", format, diff)
+			}
+		}
+	}
+}
+
+func TestNewVersionLabels(t *testing.T) {
+	cases := map[string]struct {
+		strategy string
+		expected []string
+	}{
+		"canary":  {strategy: "canary", expected: []string{"Canary", "Stable"}},
+		"rolling": {strategy: "rolling", expected: []string{"New", "Old"}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			report := New(&rollout.DetailedRollout{Rollout: rollout.Rollout{Id: "rol-1", Strategy: tc.strategy}})
+
+			var actual []string
+			for _, version := range report.Versions {
+				actual = append(actual, version.Name)
+			}
+
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("version labels mismatch (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package report
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"text/template"
+)
+
+var templateFuncs = map[string]interface{}{
+	"cell":          markdownCell,
+	"verifications": allVerifications,
+}
+
+// markdownCell keeps a value within its table cell
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func allVerifications(r *Report) []Verification {
+	var verifications []Verification
+	for _, reportPhase := range r.Phases {
+		verifications = append(verifications, reportPhase.Verifications...)
+	}
+
+	return append(verifications, r.BackgroundVerifications...)
+}
+
+var markdownTemplate = template.Must(template.New("markdown").Funcs(templateFuncs).Parse(`# Rollout {{ .RolloutId }}
+
+| | |
+|---|---|
+| SpotDeployment | {{ cell .SpotDeployment }} |
+| Cluster | {{ cell .ClusterId }} |
+| Namespace | {{ cell .Namespace }} |
+| Strategy | {{ .Strategy }} |
+| Status | {{ .Status }} |
+| Start Time | {{ .StartTime }} |
+| End Time | {{ .EndTime }} |
+| Duration | {{ .Duration }} |
+
+## Versions
+
+| | Version | Service | Traffic | Desired | Ready | In Progress | Failed |
+|---|---|---|---|---|---|---|---|
+{{- range .Versions }}
+| {{ .Name }} | {{ cell .Version }} | {{ cell .K8sService }} | {{ .TrafficPercentage }}% | {{ .Replicas.Desired }} | {{ .Replicas.Ready }} | {{ .Replicas.InProgress }} | {{ .Replicas.Failed }} |
+{{- end }}
+
+## Timeline
+{{ if .Phases }}
+| Phase | Name | Status | Weight | Start Time | Paused At | Verified At | End Time | Duration |
+|---|---|---|---|---|---|---|---|---|
+{{- range .Phases }}
+| {{ .Index }} | {{ cell .Name }} | {{ .Status }} | {{ .Weight }} | {{ .StartTime }} | {{ .PausedAt }} | {{ .VerifiedAt }} | {{ .EndTime }} | {{ .Duration }} |
+{{- end }}
+{{ else }}
+No phases started.
+{{ end }}
+{{- if .HeaderRoutes }}
+## Header Routes
+
+| Step | Header | Match |
+|---|---|---|
+{{- range .HeaderRoutes }}
+| {{ cell .Step }} | {{ cell .HeaderName }} | {{ cell .Match }} |
+{{- end }}
+{{ end }}
+## Verifications
+{{ with verifications . }}
+{{- range . }}
+### {{ .Step }} / {{ .MetricName }}
+
+| | |
+|---|---|
+| Provider | {{ cell .Provider }} |
+| Status | {{ .Status }} |
+{{- if .Query }}
+| Query | ` + "`{{ cell .Query }}`" + ` |
+{{- end }}
+{{- if .FailureCondition }}
+| Failure Condition | ` + "`{{ cell .FailureCondition }}`" + ` |
+{{- end }}
+| Failures | {{ .Failures }} |
+{{ if .DataPoints }}
+| Timestamp | Value | Status |
+|---|---|---|
+{{- range .DataPoints }}
+| {{ cell .Timestamp }} | {{ cell .Value }} | {{ cell .Status }} |
+{{- end }}
+{{ else }}
+No data points measured.
+{{ end }}
+{{- end }}
+{{- else }}
+No verifications.
+{{ end -}}
+`))
+
+var htmlTemplate = htmltemplate.Must(htmltemplate.New("html").Funcs(templateFuncs).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Rollout {{ .RolloutId }}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+code { background: #f4f4f4; }
+</style>
+</head>
+<body>
+<h1>Rollout {{ .RolloutId }}</h1>
+<table>
+<tr><th>SpotDeployment</th><td>{{ .SpotDeployment }}</td></tr>
+<tr><th>Cluster</th><td>{{ .ClusterId }}</td></tr>
+<tr><th>Namespace</th><td>{{ .Namespace }}</td></tr>
+<tr><th>Strategy</th><td>{{ .Strategy }}</td></tr>
+<tr><th>Status</th><td>{{ .Status }}</td></tr>
+<tr><th>Start Time</th><td>{{ .StartTime }}</td></tr>
+<tr><th>End Time</th><td>{{ .EndTime }}</td></tr>
+<tr><th>Duration</th><td>{{ .Duration }}</td></tr>
+</table>
+<h2>Versions</h2>
+<table>
+<tr><th></th><th>Version</th><th>Service</th><th>Traffic</th><th>Desired</th><th>Ready</th><th>In Progress</th><th>Failed</th></tr>
+{{- range .Versions }}
+<tr><th>{{ .Name }}</th><td>{{ .Version }}</td><td>{{ .K8sService }}</td><td>{{ .TrafficPercentage }}%</td><td>{{ .Replicas.Desired }}</td><td>{{ .Replicas.Ready }}</td><td>{{ .Replicas.InProgress }}</td><td>{{ .Replicas.Failed }}</td></tr>
+{{- end }}
+</table>
+<h2>Timeline</h2>
+{{- if .Phases }}
+<table>
+<tr><th>Phase</th><th>Name</th><th>Status</th><th>Weight</th><th>Start Time</th><th>Paused At</th><th>Verified At</th><th>End Time</th><th>Duration</th></tr>
+{{- range .Phases }}
+<tr><td>{{ .Index }}</td><td>{{ .Name }}</td><td>{{ .Status }}</td><td>{{ .Weight }}</td><td>{{ .StartTime }}</td><td>{{ .PausedAt }}</td><td>{{ .VerifiedAt }}</td><td>{{ .EndTime }}</td><td>{{ .Duration }}</td></tr>
+{{- end }}
+</table>
+{{- else }}
+<p>No phases started.</p>
+{{- end }}
+{{- if .HeaderRoutes }}
+<h2>Header Routes</h2>
+<table>
+<tr><th>Step</th><th>Header</th><th>Match</th></tr>
+{{- range .HeaderRoutes }}
+<tr><td>{{ .Step }}</td><td>{{ .HeaderName }}</td><td>{{ .Match }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+<h2>Verifications</h2>
+{{- with verifications . }}
+{{- range . }}
+<h3>{{ .Step }} / {{ .MetricName }}</h3>
+<table>
+<tr><th>Provider</th><td>{{ .Provider }}</td></tr>
+<tr><th>Status</th><td>{{ .Status }}</td></tr>
+{{- if .Query }}
+<tr><th>Query</th><td><code>{{ .Query }}</code></td></tr>
+{{- end }}
+{{- if .FailureCondition }}
+<tr><th>Failure Condition</th><td><code>{{ .FailureCondition }}</code></td></tr>
+{{- end }}
+<tr><th>Failures</th><td>{{ .Failures }}</td></tr>
+</table>
+{{- if .DataPoints }}
+<table>
+<tr><th>Timestamp</th><th>Value</th><th>Status</th></tr>
+{{- range .DataPoints }}
+<tr><td>{{ .Timestamp }}</td><td>{{ .Value }}</td><td>{{ .Status }}</td></tr>
+{{- end }}
+</table>
+{{- else }}
+<p>No data points measured.</p>
+{{- end }}
+{{- end }}
+{{- else }}
+<p>No verifications.</p>
+{{- end }}
+</body>
+</html>
+`))
@@ -1,11 +1,13 @@
 package viewcontroller
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/fatih/color"
+	"gopkg.in/yaml.v3"
 	"io"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"os"
 	"reflect"
 	"sort"
@@ -24,12 +26,19 @@ import (
 
 const (
 	tableFormat       = "%-21s%v\n"
+	detailsFormat     = "%-23s%v\n"
 	columnPrefix      = "│"
 	separatingRawPart = "──────────"
 	rawTemplate       = "%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n"
 	subRowOffset      = "  "
 )
 
+// machine-readable outputs
+const (
+	OutputJson = "json"
+	OutputYaml = "yaml"
+)
+
 // icons
 const (
 	iconWaiting    = "◷"
@@ -84,35 +93,102 @@ func (c *viewController) colorize(text string) string {
 // https://github.com/argoproj/argo-rollouts/blob/a6dbe0ec2db3f02cf695ba3c972db72cecabaefb/pkg/kubectl-argo-rollouts/viewcontroller/viewcontroller.go#L53
 type RolloutViewController struct {
 	*viewController
+	repository      repositories.RolloutRepository
 	rolloutId       string
 	rollout         *rollout.DetailedRollout
 	previousRollout *rollout.DetailedRollout
+	mutex           sync.Mutex
+	output          string
+	lastDocument    []byte
+	details         bool
+	metrics         *metricsView
+	interval        time.Duration
+	recorder        *repositories.SessionRecorder
 }
 
-func NewRolloutViewController(rolloutId string, noColor bool) *RolloutViewController {
+func NewRolloutViewController(repository repositories.RolloutRepository, rolloutId string, noColor bool) *RolloutViewController {
 	vc := newViewController(noColor)
 
 	return &RolloutViewController{
 		viewController: vc,
+		repository:     repository,
 		rolloutId:      rolloutId,
 	}
 }
 
-func (c *RolloutViewController) GetRollout() (*rollout.DetailedRollout, error) {
-	detailedRolloutBuilder := builders.NewDetailedRolloutBuilder(repositories.NewRolloutRepository())
+// WithOutput renders the rollout as json or yaml documents instead of the table view
+func (c *RolloutViewController) WithOutput(output string) *RolloutViewController {
+	if output == "yml" {
+		output = OutputYaml
+	}
+
+	c.output = output
+	return c
+}
+
+// WithInterval sets the base interval between rollout refreshes while watching
+func (c *RolloutViewController) WithInterval(interval time.Duration) *RolloutViewController {
+	c.interval = interval
+	return c
+}
+
+// WithRecorder records every distinct rollout that is fetched, to be replayed later
+func (c *RolloutViewController) WithRecorder(recorder *repositories.SessionRecorder) *RolloutViewController {
+	c.recorder = recorder
+	return c
+}
+
+// WithObservedRollout starts watching from an already fetched rollout, which is only rendered again once it changes
+func (c *RolloutViewController) WithObservedRollout(detailedRollout *rollout.DetailedRollout) *RolloutViewController {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.previousRollout = detailedRollout
+	return c
+}
+
+// WithDetails adds the query, failure condition and data points of every verification to the table view
+func (c *RolloutViewController) WithDetails(details bool) *RolloutViewController {
+	c.details = details
+	return c
+}
+
+func (c *RolloutViewController) GetRollout(ctx context.Context) (*rollout.DetailedRollout, error) {
+	detailedRolloutBuilder := builders.NewDetailedRolloutBuilder(c.repository)
 
 	if c.previousRollout == nil {
-		return detailedRolloutBuilder.WithStrategy().Build(c.rolloutId)
+		detailedRolloutBuilder.WithStrategy()
+	}
+
+	detailedRollout, err := detailedRolloutBuilder.Build(ctx, c.rolloutId)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.previousRollout != nil {
+		detailedRollout.Definition.Strategy = c.previousRollout.Definition.Strategy
 	}
 
-	detailedRollout, err := detailedRolloutBuilder.Build(c.rolloutId)
-	detailedRollout.Definition.Strategy = c.previousRollout.Definition.Strategy
+	c.record(detailedRollout)
 
-	return detailedRollout, err
+	return detailedRollout, nil
+}
+
+// record is called before printing, since printing adjusts the phases of the rollout
+func (c *RolloutViewController) record(detailedRollout *rollout.DetailedRollout) {
+	if c.recorder == nil {
+		return
+	}
+
+	if err := c.recorder.Record(detailedRollout, time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+	}
 }
 
+// PrintRollout prints a copy of the rollout, as printing adjusts the phase statuses and orders the verifications.
+// The given rollout is left as fetched, to be compared with the next one.
 func (c *RolloutViewController) PrintRollout(detailedRollout *rollout.DetailedRollout) {
-	c.rollout = detailedRollout
+	c.rollout = detailedRollout.DeepCopy()
 	fmt.Fprintf(c.writer, tableFormat, "Start Time:", c.rollout.StartTime)
 	if c.rollout.EndTime != "" {
 		fmt.Fprintf(c.writer, tableFormat, "End Time:", c.rollout.EndTime)
@@ -130,33 +206,115 @@ func (c *RolloutViewController) PrintRollout(detailedRollout *rollout.DetailedRo
 	}
 
 	c.printPhases()
+
+	if c.details {
+		c.printVerificationDetails()
+	}
+}
+
+// PrintRolloutDocument writes the rollout as a json or yaml document, unless it equals the previously written one.
+// Compact json documents take a single line each, so a watched rollout becomes a newline delimited json stream.
+func (c *RolloutViewController) PrintRolloutDocument(detailedRollout *rollout.DetailedRollout, compact bool) error {
+	var document []byte
+	var err error
+
+	switch c.output {
+	case OutputJson:
+		if compact {
+			document, err = json.Marshal(detailedRollout)
+		} else {
+			document, err = json.MarshalIndent(detailedRollout, "", "  ")
+		}
+		document = append(document, '\n')
+	case OutputYaml:
+		document, err = yaml.Marshal(detailedRollout)
+		if compact {
+			document = append([]byte("---\n"), document...)
+		}
+	default:
+		return fmt.Errorf("error: Unknown output %s", c.output)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error: Failed to convert rollout to %s - %w", c.output, err)
+	}
+
+	if bytes.Equal(document, c.lastDocument) {
+		return nil
+	}
+
+	c.lastDocument = document
+	_, err = c.writer.Write(document)
+
+	return err
 }
 
 // This code was copied with adjustments from
 // https://github.com/argoproj/argo-rollouts/blob/a6dbe0ec2db3f02cf695ba3c972db72cecabaefb/pkg/kubectl-argo-rollouts/viewcontroller/viewcontroller.go#L144
+// Run refreshes the rollout until it is done or the context is done. The refreshes slow down while the rollout
+// does not change.
 func (c *RolloutViewController) Run(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
 
-	go wait.Until(func() {
-		for c.processRollout() {
-		}
-	}, time.Second, ctx.Done())
-	<-ctx.Done()
+	pollAdaptively(ctx, c.interval, nil, func() (bool, bool) {
+		changed := c.processRollout(ctx)
+		lastRollout := c.LastObservedRollout()
+
+		return changed, lastRollout != nil && lastRollout.Status.IsDone()
+	})
 }
 
-func (c *RolloutViewController) processRollout() bool {
-	rolloutInfo, err := c.GetRollout()
+// processRollout renders the rollout when it changed since the previous refresh and reports whether it did
+func (c *RolloutViewController) processRollout(ctx context.Context) bool {
+	rolloutInfo, err := c.GetRollout(ctx)
 	if err != nil {
-		fmt.Printf("%s/n", err)
+		if ctx.Err() != nil {
+			return false
+		}
+
+		if c.output != "" {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+		} else {
+			fmt.Printf("%s\n", err)
+		}
+		return false
+	}
+
+	if reflect.DeepEqual(c.previousRollout, rolloutInfo) {
 		return false
 	}
 
-	if !reflect.DeepEqual(c.previousRollout, rolloutInfo) {
+	c.render(rolloutInfo)
+
+	c.mutex.Lock()
+	c.previousRollout = rolloutInfo
+	c.mutex.Unlock()
+
+	return true
+}
+
+// LastObservedRollout returns the latest rollout state seen while watching, nil if none was fetched yet
+func (c *RolloutViewController) LastObservedRollout() *rollout.DetailedRollout {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.previousRollout
+}
+
+// render reprints the whole view for every observed change, documents are appended instead
+func (c *RolloutViewController) render(rolloutInfo *rollout.DetailedRollout) {
+	switch {
+	case c.output != "":
+		if err := c.PrintRolloutDocument(rolloutInfo, true); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+		}
+	case c.metrics != nil:
+		c.clear()
+		c.PrintMetrics(rolloutInfo)
+	default:
 		c.clear()
 		c.PrintRollout(rolloutInfo)
-		c.previousRollout = rolloutInfo
 	}
-	return true
 }
 
 // This code was copied with adjustments from
@@ -323,7 +481,7 @@ func (c *RolloutViewController) printVersionStatus(status rollout.VersionStatus,
 	}
 }
 
-func (c *RolloutViewController) statusIcon(status rollout.Status) string {
+func (c *viewController) statusIcon(status rollout.Status) string {
 	switch status {
 	case rollout.Pending:
 		return c.colorize(iconWaiting)
@@ -347,7 +505,7 @@ func (c *RolloutViewController) statusIcon(status rollout.Status) string {
 	}
 }
 
-func (c *RolloutViewController) phaseStatusIcon(status phase.Status) string {
+func (c *viewController) phaseStatusIcon(status phase.Status) string {
 	switch status {
 	case phase.Pending:
 		return c.colorize(iconWaiting)
@@ -368,7 +526,7 @@ func (c *RolloutViewController) phaseStatusIcon(status phase.Status) string {
 	}
 }
 
-func (c *RolloutViewController) verificationStatusIcon(status verification.Status) string {
+func (c *viewController) verificationStatusIcon(status verification.Status) string {
 	switch status {
 	case verification.Successful:
 		return c.colorize(iconOk)
@@ -385,20 +543,7 @@ func (c *RolloutViewController) verificationStatusIcon(status verification.Statu
 	}
 }
 
-func (c *RolloutViewController) calculateActivePhase() int {
-	for i, rolloutPhase := range c.rollout.Phases {
-		for _, uncompletedStatus := range phase.UncompletedStatuses {
-			phaseStatus := rolloutPhase.Status
-			if phaseStatus == uncompletedStatus {
-				return i + 1
-			}
-		}
-	}
-
-	return len(c.rollout.Phases)
-}
-
-func (c *RolloutViewController) orderVerifications(verifications []verification.Verification) []verification.Verification {
+func (c *viewController) orderVerifications(verifications []verification.Verification) []verification.Verification {
 	sort.Slice(verifications, func(i, j int) bool {
 		return verification.StatusOrder[verifications[i].Status] < verification.StatusOrder[verifications[j].Status]
 	})
@@ -407,13 +552,7 @@ func (c *RolloutViewController) orderVerifications(verifications []verification.
 }
 
 func (c *RolloutViewController) printPhasesNumber() {
-	for _, status := range rollout.CompletedStatuses {
-		if status == c.rollout.Status {
-			fmt.Fprintf(c.writer, tableFormat, "Phases:", fmt.Sprintf("%d", len(c.rollout.Phases)))
-			return
-		}
-	}
-	fmt.Fprintf(c.writer, tableFormat, "Phases:", fmt.Sprintf("%d/%d", c.calculateActivePhase(), len(c.rollout.Phases)))
+	fmt.Fprintf(c.writer, tableFormat, "Phases:", c.rollout.PhaseProgress())
 }
 
 func (c *RolloutViewController) printBackgroundVerifications() {
@@ -431,6 +570,73 @@ func (c *RolloutViewController) printBackgroundVerifications() {
 	}
 }
 
+func (c *RolloutViewController) printVerificationDetails() {
+	var hasVerifications bool
+	for _, rolloutPhase := range c.rollout.Phases {
+		hasVerifications = hasVerifications || len(rolloutPhase.Verifications) > 0
+	}
+
+	backgroundVerifications := c.rollout.GetBackgroundVerifications()
+	if hasVerifications == false && len(backgroundVerifications) == 0 {
+		return
+	}
+
+	fmt.Fprintf(c.writer, "\n%s\n", "Verification Details:")
+
+	for i, rolloutPhase := range c.rollout.Phases {
+		for _, verificationItem := range c.orderVerifications(rolloutPhase.Verifications) {
+			c.printVerificationDetail(fmt.Sprintf("%s %s", converter.PhaseIndex(i+1), converter.PhaseName(rolloutPhase)), verificationItem)
+		}
+	}
+
+	for _, verificationItem := range c.orderVerifications(backgroundVerifications) {
+		c.printVerificationDetail(model.BackgroundVerificationLabel, verificationItem)
+	}
+}
+
+func (c *RolloutViewController) printVerificationDetail(step string, verificationItem verification.Verification) {
+	fmt.Fprintf(c.writer, "%s%s / %s (%s) %s %s\n", subRowOffset,
+		c.colorizeWith(step, color.Bold), c.colorizeWith(verificationItem.MetricName, color.Bold), verificationItem.Provider,
+		c.verificationStatusIcon(verificationItem.Status), converter.VerificationStatus(verificationItem))
+
+	offset := subRowOffset + subRowOffset
+	if verificationItem.Query != "" {
+		fmt.Fprintf(c.writer, detailsFormat, offset+"Query:", verificationItem.Query)
+	}
+
+	if verificationItem.FailureCondition != "" {
+		fmt.Fprintf(c.writer, detailsFormat, offset+"FailureCondition:", verificationItem.FailureCondition)
+	}
+
+	if verificationItem.Interval != "" || verificationItem.Count > 0 {
+		fmt.Fprintf(c.writer, detailsFormat, offset+"Interval:", fmt.Sprintf("%s, count: %d", model.Stub(verificationItem.Interval), verificationItem.Count))
+	}
+
+	failed := verificationItem.FailedMeasurements()
+	fmt.Fprintf(c.writer, detailsFormat, offset+"Failures:", fmt.Sprintf("%d of %d allowed, %d remaining before the limit",
+		failed, verificationItem.FailureLimit, verificationItem.RemainingFailures()))
+
+	if len(verificationItem.DataPoints) == 0 {
+		fmt.Fprintf(c.writer, detailsFormat, offset+"DataPoints:", "none measured yet")
+		return
+	}
+
+	fmt.Fprintf(c.writer, "%s%s\n", offset, "DataPoints:")
+
+	var buffer bytes.Buffer
+	writer := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "%s%s\t%s\t%s\n", offset+subRowOffset, "TIMESTAMP", "VALUE", "STATUS")
+
+	for _, dataPoint := range verificationItem.DataPoints {
+		fmt.Fprintf(writer, "%s%s\t%s\t%s %s\n", offset+subRowOffset,
+			model.Stub(dataPoint.Timestamp), model.Stub(dataPoint.Value),
+			c.verificationStatusIcon(verification.Status(dataPoint.Status)), model.Stub(dataPoint.Status))
+	}
+
+	writer.Flush()
+	fmt.Fprint(c.writer, buffer.String())
+}
+
 func (c *RolloutViewController) printVersion(label string, status rollout.VersionStatus, labelColor color.Attribute) {
 	fmt.Fprintf(c.writer, fmt.Sprintf("%s:\n", label))
 	c.printVersionStatus(status, labelColor)
@@ -0,0 +1,73 @@
+package viewcontroller
+
+import (
+	"bytes"
+	"context"
+	"spot-oceancd-cli/pkg/oceancd/model/phase"
+	"spot-oceancd-cli/pkg/oceancd/model/rollout"
+	"spot-oceancd-cli/pkg/oceancd/model/verification"
+	"testing"
+)
+
+func TestRolloutViewControllerProcessUnchangedRollout(t *testing.T) {
+	repository := newFakeRolloutRepository()
+
+	// printing drops the phases after the fully promoted one and orders the verifications by status
+	repository.PutRollout(rollout.DetailedRollout{
+		Rollout: rollout.Rollout{Id: "rol-promoted", Status: rollout.InProgress, SpotDeployment: "checkout"},
+		Phases: []phase.Phase{
+			{Name: "first", Status: phase.FullPromoted, Verifications: []verification.Verification{
+				{MetricName: "latency", Status: verification.Successful},
+				{MetricName: "errors", Status: verification.Failed},
+			}},
+			{Name: "second", Status: phase.Pending},
+		},
+		Verifications: []verification.Verification{
+			{MetricName: "latency", Status: verification.Successful, Step: "first"},
+			{MetricName: "errors", Status: verification.Failed, Step: "first"},
+		},
+	}, map[string]interface{}{
+		"strategy": map[string]interface{}{
+			"canary": map[string]interface{}{
+				"steps": []interface{}{map[string]interface{}{"name": "first"}, map[string]interface{}{"name": "second"}},
+			},
+		},
+	})
+
+	for _, details := range []bool{false, true} {
+		buffer := &bytes.Buffer{}
+		controller := NewRolloutViewController(repository, "rol-promoted", true).WithDetails(details)
+		controller.writer = buffer
+
+		if changed := controller.processRollout(context.Background()); changed == false {
+			t.Fatalf("details %v: expected the first rollout to be rendered", details)
+		}
+
+		buffer.Reset()
+		if changed := controller.processRollout(context.Background()); changed {
+			t.Errorf("details %v: expected the unchanged rollout not to be rendered again, got:\n%s", details, buffer.String())
+		}
+
+		if status := controller.LastObservedRollout().Phases[1].Status; status != phase.Pending {
+			t.Errorf("details %v: expected the observed rollout to be left as fetched, got phase status %s", details, status)
+		}
+	}
+}
+
+func TestRolloutViewControllerWithObservedRollout(t *testing.T) {
+	repository := newFakeRolloutRepository()
+	controller := NewRolloutViewController(repository, "rol-eu", true)
+	controller.writer = &bytes.Buffer{}
+
+	detailedRollout, err := controller.GetRollout(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	controller.WithObservedRollout(detailedRollout)
+	controller.PrintRollout(detailedRollout)
+
+	if changed := controller.processRollout(context.Background()); changed {
+		t.Errorf("expected the already printed rollout not to be rendered again")
+	}
+}